@@ -0,0 +1,62 @@
+/*
+Copyright © 2024 Victor Hang
+*/
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Banh-Canh/ytui/internal/credentials"
+	"github.com/Banh-Canh/ytui/pkg/youtube"
+)
+
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Manage YouTube account credentials",
+	Long: `
+Manage the OAuth credentials ytui uses to access your YouTube account.
+
+Tokens are kept in the OS keyring when available, or in a passphrase-encrypted
+file otherwise (see credentials.backend in the configuration).`,
+}
+
+var authLogoutCmd = &cobra.Command{
+	Use:   "logout",
+	Short: "Revoke and delete the stored YouTube token",
+	Long: `
+Revoke the stored OAuth token at Google and delete it from the credential store.
+The token is deleted even when Google can't be reached to revoke it, with a warning.
+The next access to your subscriptions will ask you to log in again.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := credentials.OpenConfigured(credentials.PromptPassphrase)
+		if err != nil {
+			return err
+		}
+
+		yt := youtube.New(youtube.Config{TokenStore: credentials.TokenStore{Store: store}})
+		ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+		defer cancel()
+		err = yt.Auth().Logout(ctx)
+		if errors.Is(err, youtube.ErrRevokeFailed) {
+			fmt.Printf("Logged out, stored token deleted. Warning: it could not be revoked at Google (%v);\n"+
+				"revoke it at https://myaccount.google.com/permissions if needed.\n", err)
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("logout failed: %w", err)
+		}
+
+		fmt.Println("Logged out, stored token revoked and deleted.")
+		return nil
+	},
+}
+
+func init() {
+	authCmd.AddCommand(authLogoutCmd)
+	RootCmd.AddCommand(authCmd)
+}
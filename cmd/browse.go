@@ -5,8 +5,6 @@ package cmd
 
 import (
 	"github.com/spf13/cobra"
-
-	"github.com/Banh-Canh/ytui/internal/ui"
 )
 
 var browseCmd = &cobra.Command{
@@ -19,10 +17,11 @@ Navigate through search results, subscribed channels, and watch history.
 Use arrow keys or hjkl to navigate, Enter to open, Space/p to play.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		// Start TUI
-		ui.Menu()
+		startMenu()
 	},
 }
 
 func init() {
+	browseCmd.Flags().BoolVarP(&incognitoFlag, "incognito", "i", false, incognitoUsage)
 	RootCmd.AddCommand(browseCmd)
 }
\ No newline at end of file
@@ -0,0 +1,267 @@
+/*
+Copyright © 2024 Victor Hang
+*/
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/Banh-Canh/ytui/internal/config"
+	"github.com/Banh-Canh/ytui/internal/history"
+	"github.com/Banh-Canh/ytui/internal/utils"
+	"github.com/Banh-Canh/ytui/pkg/youtube"
+)
+
+var (
+	historyLimitFlag    int
+	historyChannelFlag  string
+	historySinceFlag    string
+	historyUntilFlag    string
+	historyYesFlag      bool
+	historyBackfillFlag bool
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Manage the watch history",
+	Long: `
+List, search, delete and import the videos of the watch history of the active profile.`,
+}
+
+var historyListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List watched videos, last watched first",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return listHistory("")
+	},
+}
+
+var historySearchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Search watched videos by title or channel",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return listHistory(strings.Join(args, " "))
+	},
+}
+
+var historyRmCmd = &cobra.Command{
+	Use:   "rm <video ID or URL>...",
+	Short: "Delete videos from the watch history",
+	Long: `
+Delete videos from the watch history along with every play of them.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		videoIDs := make([]string, 0, len(args))
+		for _, arg := range args {
+			videoID, err := historyVideoID(arg)
+			if err != nil {
+				return err
+			}
+			videoIDs = append(videoIDs, videoID)
+		}
+
+		store, err := openHistory()
+		if err != nil {
+			return err
+		}
+		defer store.Close()
+		removed, err := store.Remove(videoIDs...)
+		if err != nil {
+			return fmt.Errorf("failed to delete from history: %w", err)
+		}
+		fmt.Printf("Deleted %d video(s) and %d play(s) from history.\n", removed.Len(), len(removed.Plays))
+		return nil
+	},
+}
+
+var historyClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Delete the whole watch history",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !historyYesFlag && !confirm("Delete the whole watch history?") {
+			return nil
+		}
+		store, err := openHistory()
+		if err != nil {
+			return err
+		}
+		defer store.Close()
+		if err := store.Clear(); err != nil {
+			return fmt.Errorf("failed to clear history: %w", err)
+		}
+		fmt.Println("Watch history cleared.")
+		return nil
+	},
+}
+
+var historyImportCmd = &cobra.Command{
+	Use:   "import <watch-history.json|watch-history.html>",
+	Short: "Import the watch history of a Google Takeout export",
+	Long: `
+Import the YouTube watch history exported by Google Takeout, as watch-history.json or
+watch-history.html. Plays already in the history, played in ytui or from an earlier
+import, are skipped: a play of the same video within 5 minutes is taken for the same one.
+
+Takeout only has titles, channels and dates. --backfill looks up the length and
+thumbnails of the imported videos on the Invidious instance, which can take a while
+for a long history; it can be interrupted and run again.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		takeout, err := history.ParseTakeout(file)
+		if err != nil {
+			return err
+		}
+
+		store, err := openHistory()
+		if err != nil {
+			return err
+		}
+		added, err := store.Import(takeout.Plays)
+		store.Close()
+		if err != nil {
+			return fmt.Errorf("failed to import history: %w", err)
+		}
+		fmt.Printf("Imported %d play(s), %d already in history, %d skipped (ads, removed videos).\n",
+			added, len(takeout.Plays)-added, takeout.Skipped)
+
+		if !historyBackfillFlag {
+			return nil
+		}
+		configDir, err := config.GetConfigDirPath()
+		if err != nil {
+			return err
+		}
+		yt := youtube.New(youtube.Config{
+			InvidiousURL: viper.GetString("invidious.instance"),
+			ProxyURL:     viper.GetString("invidious.proxy"),
+			Logger:       utils.SlogLogger(),
+		}, youtube.WithTimeout(viper.GetDuration("invidious.timeout")))
+		updated, err := history.Backfill(yt.Search().VideoInfoBatch, func(done, total int) {
+			fmt.Printf("\rBackfilling metadata: %d/%d", done, total)
+		}, configDir)
+		fmt.Println()
+		if err != nil {
+			return fmt.Errorf("failed to backfill metadata: %w", err)
+		}
+		fmt.Printf("Backfilled %d video(s).\n", updated)
+		return nil
+	},
+}
+
+// openHistory opens the history database of the active profile
+func openHistory() (*history.Store, error) {
+	configDir, err := config.GetConfigDirPath()
+	if err != nil {
+		return nil, err
+	}
+	return history.OpenInDir(configDir)
+}
+
+// listHistory prints the watched videos matching query and the filter flags
+func listHistory(query string) error {
+	filter := history.Filter{Query: query, Channel: historyChannelFlag}
+	var err error
+	if filter.From, err = parseHistoryDate(historySinceFlag, false, time.Now()); err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+	if filter.To, err = parseHistoryDate(historyUntilFlag, true, time.Now()); err != nil {
+		return fmt.Errorf("invalid --until: %w", err)
+	}
+
+	store, err := openHistory()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	entries, err := store.Find(filter)
+	if err != nil {
+		return fmt.Errorf("failed to read history: %w", err)
+	}
+	if historyLimitFlag > 0 && len(entries) > historyLimitFlag {
+		entries = entries[:historyLimitFlag]
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "LAST WATCHED\tPLAYS\tID\tCHANNEL\tTITLE")
+	for _, entry := range entries {
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\n",
+			entry.LastWatched.Local().Format("2006-01-02 15:04"), entry.WatchCount,
+			entry.Video.VideoID, entry.Video.Author, entry.Video.Title)
+	}
+	return w.Flush()
+}
+
+// parseHistoryDate parses a date (2006-01-02) or an age in days (7d) or as a Go
+// duration (12h) before now. An empty value is the zero time. A date is the start of its
+// day, or of the next one for an upper bound so that the day itself is included.
+func parseHistoryDate(value string, upper bool, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if date, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		if upper {
+			return date.AddDate(0, 0, 1), nil
+		}
+		return date, nil
+	}
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return now.AddDate(0, 0, -n), nil
+		}
+	}
+	age, err := time.ParseDuration(value)
+	if err != nil || age < 0 {
+		return time.Time{}, fmt.Errorf("%q is neither a date (2006-01-02) nor an age (7d, 12h)", value)
+	}
+	return now.Add(-age), nil
+}
+
+// historyVideoID accepts a video ID or the URL of a video
+func historyVideoID(arg string) (string, error) {
+	if youtube.IsVideoID(arg) {
+		return arg, nil
+	}
+	if ref, err := youtube.ParseURL(arg); err == nil && ref.VideoID != "" {
+		return ref.VideoID, nil
+	}
+	return "", fmt.Errorf("%q is not a video ID or URL", arg)
+}
+
+// confirm asks a yes/no question on the terminal, defaulting to no
+func confirm(question string) bool {
+	fmt.Printf("%s [y/N] ", question)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+func init() {
+	for _, command := range []*cobra.Command{historyListCmd, historySearchCmd} {
+		command.Flags().IntVarP(&historyLimitFlag, "limit", "n", 0, "Show at most this many videos (0 for all)")
+		command.Flags().StringVarP(&historyChannelFlag, "channel", "c", "", "Only videos of this channel (ID or name)")
+		command.Flags().StringVar(&historySinceFlag, "since", "", "Only videos last watched since a date (2006-01-02) or an age (7d, 12h)")
+		command.Flags().StringVar(&historyUntilFlag, "until", "", "Only videos last watched on or before a date (2006-01-02), or before an age (7d, 12h)")
+	}
+	historyClearCmd.Flags().BoolVarP(&historyYesFlag, "yes", "y", false, "Don't ask for confirmation")
+	historyImportCmd.Flags().BoolVar(&historyBackfillFlag, "backfill", false, "Look up the length and thumbnails of the videos without them")
+
+	historyCmd.AddCommand(historyListCmd, historySearchCmd, historyRmCmd, historyClearCmd, historyImportCmd)
+	RootCmd.AddCommand(historyCmd)
+}
@@ -14,16 +14,22 @@ import (
 	"go.uber.org/zap/zapcore"
 
 	"github.com/Banh-Canh/ytui/internal/config"
+	"github.com/Banh-Canh/ytui/internal/credentials"
 	"github.com/Banh-Canh/ytui/internal/ui"
 	"github.com/Banh-Canh/ytui/internal/utils"
 )
 
 var (
-	versionFlag  bool
-	version      string
-	logLevelFlag string
+	versionFlag   bool
+	version       string
+	logLevelFlag  string
+	profileFlag   string
+	incognitoFlag bool
 )
 
+// incognitoUsage describes --incognito, shared by the commands starting the TUI
+const incognitoUsage = "Don't record plays in the watch history, resume positions or the YouTube account's history"
+
 // rootCmd represents the base command when called without any subcommands
 var RootCmd = &cobra.Command{
 	Use:   "ytui",
@@ -31,7 +37,8 @@ var RootCmd = &cobra.Command{
 	Long: `
 ytui is a TUI tool that allows users to browse and search YouTube videos and play them in their local player.
 Navigate through search results, subscribed channels, and watch history using an interactive interface.
-The configuration file is autogenerated on first run at *$HOME/.config/ytui/config.yaml*`,
+The configuration file is autogenerated on first run at *$HOME/.config/ytui/config.yaml*
+Named profiles (--profile) live in *$HOME/.config/ytui/profiles/<name>/*`,
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
 		// Initialize configuration here
 		initConfig()
@@ -50,22 +57,40 @@ The configuration file is autogenerated on first run at *$HOME/.config/ytui/conf
 		if versionFlag {
 			fmt.Printf("%s", version)
 		} else {
-			ui.Menu()
+			startMenu()
 		}
 	},
 }
 
+// startMenu opens the credential store and launches the TUI
+func startMenu() {
+	store, err := credentials.OpenConfigured(credentials.PromptPassphrase)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	// Account mode needs the token, so unlock the store before the TUI owns the terminal
+	if config.AccountEnabled() {
+		if err := credentials.Unlock(store); err != nil {
+			fmt.Fprintf(os.Stderr, "error, couldn't unlock credential store: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	ui.Menu(store, incognitoFlag)
+}
+
 func initConfig() {
 	// Your configuration initialization logic
-	configDir, err := config.GetConfigDirPath()
+	if err := config.SetProfile(profileFlag); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	rootDir, err := config.GetRootConfigDirPath()
 	if err != nil {
 		os.Exit(1)
 	}
-	configPath := filepath.Join(configDir, "config.yaml")
-	viper.SetConfigFile(configPath)
-	config.CreateDefaultConfigFile(configPath)
-	if err := viper.ReadInConfig(); err != nil {
-		fmt.Fprintf(os.Stderr, "error, couldn't read config file: %v\n", err)
+	if err := config.Load(); err != nil {
+		fmt.Fprintf(os.Stderr, "error, %v\n", err)
 		os.Exit(1)
 	}
 	// Check if the logLevelFlag has been set, if not, fallback to config
@@ -88,8 +113,9 @@ func initConfig() {
 		fmt.Printf("Unknown log level %s, defaulting to info\n", logLevelStr)
 		logLevel = zapcore.InfoLevel
 	}
-	utils.InitializeLogger(logLevel, filepath.Join(configDir, "ytui.log"))
-	utils.Logger.Info("Initialized configuration.")
+	// The log is shared by all profiles
+	utils.InitializeLogger(logLevel, filepath.Join(rootDir, "ytui.log"))
+	utils.Logger.Info("Initialized configuration.", zap.String("profile", config.ActiveProfile()))
 }
 
 func Execute() {
@@ -102,4 +128,6 @@ func Execute() {
 func init() {
 	RootCmd.Flags().BoolVarP(&versionFlag, "version", "v", false, "Display version information")
 	RootCmd.PersistentFlags().StringVarP(&logLevelFlag, "log-level", "l", "", "Override log level (debug, info, error)")
+	RootCmd.PersistentFlags().StringVarP(&profileFlag, "profile", "P", "", "Profile to use (config, credentials and history are kept per profile)")
+	RootCmd.Flags().BoolVarP(&incognitoFlag, "incognito", "i", false, incognitoUsage)
 }
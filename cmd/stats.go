@@ -0,0 +1,64 @@
+/*
+Copyright © 2024 Victor Hang
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Banh-Canh/ytui/internal/ui"
+)
+
+var (
+	statsDaysFlag  int
+	statsJSONFlag  bool
+	statsWidthFlag int
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show watch statistics from the history",
+	Long: `
+Show videos and estimated watch time per day and week, top channels, average video
+length and streaks, computed from the watch history of the active profile.
+
+A play counts as the whole video, except the last play of a video stopped midway,
+which counts up to where it stopped. Use --json to feed your own dashboards.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if statsDaysFlag < 1 {
+			return fmt.Errorf("--days must be at least 1")
+		}
+		store, err := openHistory()
+		if err != nil {
+			return err
+		}
+		defer store.Close()
+
+		now := time.Now()
+		stats, err := store.Stats(now.AddDate(0, 0, -(statsDaysFlag-1)), now)
+		if err != nil {
+			return fmt.Errorf("failed to compute statistics: %w", err)
+		}
+
+		if statsJSONFlag {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(stats)
+		}
+		fmt.Println(ui.StatsReport(stats, statsWidthFlag))
+		return nil
+	},
+}
+
+func init() {
+	statsCmd.Flags().IntVarP(&statsDaysFlag, "days", "d", 30, "Number of days covered, today included")
+	statsCmd.Flags().BoolVar(&statsJSONFlag, "json", false, "Print the statistics as JSON")
+	statsCmd.Flags().IntVarP(&statsWidthFlag, "width", "w", 80, "Width of the report in columns")
+	RootCmd.AddCommand(statsCmd)
+}
@@ -9,9 +9,14 @@ import (
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
 
+	"github.com/Banh-Canh/ytui/internal/dearrow"
+	"github.com/Banh-Canh/ytui/internal/sponsorblock"
 	"github.com/Banh-Canh/ytui/internal/utils"
 )
 
+// PlaceholderSecret is the value written for OAuth client settings until the user fills them in
+const PlaceholderSecret = "CREATE_IN_YOUTUBE_API_CONSOLE"
+
 type Config struct {
 	Channels []string `yaml:"channels"`
 }
@@ -21,30 +26,71 @@ func CreateDefaultConfigFile(filePath string) {
 	// Struct with empty channels list
 	// Get user's home directory
 	downloadDir := xdg.UserDirs.Videos
+	if activeProfile != DefaultProfile {
+		// Keep downloads of separate identities apart as well
+		downloadDir = filepath.Join(downloadDir, activeProfile)
+	}
 	viper.SetDefault("download_dir", downloadDir)
 	viper.SetDefault("logLevel", "info")
 	viper.SetDefault("invidious", map[string]interface{}{
 		"proxy":    "",
 		"instance": "https://invidious.jing.rocks",
+		"timeout":  "30s",
 	})
 	viper.SetDefault("history", map[string]interface{}{
-		"enable": true,
+		"enable":         true,
+		"retention_days": 0,
+		"max_entries":    0,
 	})
 	viper.SetDefault("youtube", map[string]interface{}{
-		"clientID": "CREATE_IN_YOUTUBE_API_CONSOLE",
-		"secretID": "CREATE_IN_YOUTUBE_API_CONSOLE",
+		"clientID": PlaceholderSecret,
+		"secretID": PlaceholderSecret,
+		"write":    false,
+	})
+	viper.SetDefault("sponsorblock", map[string]interface{}{
+		"enable":     false,
+		"server":     sponsorblock.DefaultServerURL,
+		"categories": sponsorblock.DefaultActions(),
+	})
+	viper.SetDefault("dearrow", map[string]interface{}{
+		"enable": false,
+		"server": dearrow.DefaultServerURL,
+	})
+	viper.SetDefault("credentials", map[string]interface{}{
+		"backend": "auto",
 	})
 	viper.SetDefault("channels", map[string]interface{}{
-		"local":      true,
-		"subscribed": []string{"UCTt2AnK--mnRmICnf-CCcrw", "UCutXfzLC5wrV3SInT_tdY0w"},
+		"local":         true,
+		"subscribed":    []string{"UCTt2AnK--mnRmICnf-CCcrw", "UCutXfzLC5wrV3SInT_tdY0w"},
+		"hide_upcoming": false,
+		"hide_watched":  false,
+	})
+	viper.SetDefault("shorts", map[string]interface{}{
+		"hide":       false,
+		"tab_lookup": true,
+	})
+	viper.SetDefault("player", map[string]interface{}{
+		"live_from_start": false,
+	})
+	viper.SetDefault("watch_later", map[string]interface{}{
+		"remove_watched": true,
 	})
 	viper.SetConfigType("yaml")
 	viper.SafeWriteConfigAs(filePath) // nolint:all
 }
 
+// AccountEnabled reports whether the Google account is used, for subscriptions or write actions
+func AccountEnabled() bool {
+	return !viper.GetBool("channels.local") || viper.GetBool("youtube.write")
+}
+
+// GetConfigDirPath returns the directory of the active profile
 func GetConfigDirPath() (string, error) {
 	// Construct the directory path to the config directory
 	configDirPath := filepath.Join(xdg.ConfigHome, "ytui")
+	if activeProfile != DefaultProfile {
+		configDirPath = filepath.Join(configDirPath, "profiles", activeProfile)
+	}
 	if err := os.MkdirAll(configDirPath, os.ModePerm); err != nil {
 		panic(fmt.Sprintf("Failed to create config directory: %v", err))
 	}
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/adrg/xdg"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -42,6 +43,9 @@ func TestCreateDefaultConfigFile(t *testing.T) {
 	assert.Equal(t, "info", viper.GetString("logLevel"))
 	assert.Equal(t, "https://invidious.jing.rocks", viper.GetString("invidious.instance"))
 	assert.Equal(t, true, viper.GetBool("history.enable"))
+	assert.Equal(t, 0, viper.GetInt("history.retention_days"))
+	assert.Equal(t, 0, viper.GetInt("history.max_entries"))
+	assert.Equal(t, true, viper.GetBool("watch_later.remove_watched"))
 	assert.Equal(t, "CREATE_IN_YOUTUBE_API_CONSOLE", viper.GetString("youtube.clientID"))
 }
 
@@ -85,3 +89,33 @@ func TestReadConfig_InvalidConfigFile(t *testing.T) {
 	require.Error(t, err, "Expected an error when reading invalid config file")
 	assert.Contains(t, err.Error(), "failed to read config file")
 }
+
+func TestProfiles(t *testing.T) {
+	originalHome := xdg.ConfigHome
+	xdg.ConfigHome = t.TempDir()
+	defer func() {
+		xdg.ConfigHome = originalHome
+		require.NoError(t, SetProfile(DefaultProfile))
+	}()
+
+	// The default profile keeps the historical location
+	defaultDir, err := GetConfigDirPath()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(xdg.ConfigHome, "ytui"), defaultDir)
+
+	require.NoError(t, SetProfile("music"))
+	assert.Equal(t, "music", ActiveProfile())
+	musicDir, err := GetConfigDirPath()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(xdg.ConfigHome, "ytui", "profiles", "music"), musicDir)
+
+	profiles, err := ListProfiles()
+	require.NoError(t, err)
+	assert.Equal(t, []string{DefaultProfile, "music"}, profiles)
+}
+
+func TestSetProfile_InvalidName(t *testing.T) {
+	err := SetProfile("../escape")
+	require.Error(t, err)
+	assert.Equal(t, DefaultProfile, ActiveProfile())
+}
@@ -0,0 +1,98 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/adrg/xdg"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+
+	"github.com/Banh-Canh/ytui/internal/utils"
+)
+
+// DefaultProfile is the profile living directly in $XDG_CONFIG_HOME/ytui, as before profiles existed
+const DefaultProfile = "default"
+
+var (
+	activeProfile = DefaultProfile
+
+	profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]{0,63}$`)
+)
+
+// SetProfile selects the profile whose config, credentials and history are used from now on
+func SetProfile(name string) error {
+	if name == "" {
+		name = DefaultProfile
+	}
+	if !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q: use letters, digits, '-' and '_'", name)
+	}
+	activeProfile = name
+	return nil
+}
+
+// ActiveProfile returns the name of the selected profile
+func ActiveProfile() string {
+	return activeProfile
+}
+
+// GetRootConfigDirPath returns the top-level ytui config directory shared by all profiles
+func GetRootConfigDirPath() (string, error) {
+	rootDirPath := filepath.Join(xdg.ConfigHome, "ytui")
+	if err := os.MkdirAll(rootDirPath, os.ModePerm); err != nil {
+		return "", fmt.Errorf("failed to create config directory: %w", err)
+	}
+	return rootDirPath, nil
+}
+
+// ListProfiles returns the default profile followed by every profile created so far
+func ListProfiles() ([]string, error) {
+	rootDirPath, err := GetRootConfigDirPath()
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := []string{DefaultProfile}
+	entries, err := os.ReadDir(filepath.Join(rootDirPath, "profiles"))
+	if os.IsNotExist(err) {
+		return profiles, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var named []string
+	for _, entry := range entries {
+		if entry.IsDir() && entry.Name() != DefaultProfile && profileNamePattern.MatchString(entry.Name()) {
+			named = append(named, entry.Name())
+		}
+	}
+	sort.Strings(named)
+	return append(profiles, named...), nil
+}
+
+// Load (re)reads the active profile's config file, creating it with defaults on first use
+func Load() error {
+	configDir, err := GetConfigDirPath()
+	if err != nil {
+		return err
+	}
+	configPath := filepath.Join(configDir, "config.yaml")
+
+	// Start from a clean slate so nothing leaks over from a previously loaded profile
+	viper.Reset()
+	viper.SetConfigFile(configPath)
+	CreateDefaultConfigFile(configPath)
+	if err := viper.ReadInConfig(); err != nil {
+		return fmt.Errorf("couldn't read config file: %w", err)
+	}
+
+	if utils.Logger != nil {
+		utils.Logger.Info("Loaded profile configuration.", zap.String("profile", activeProfile), zap.String("filePath", configPath))
+	}
+	return nil
+}
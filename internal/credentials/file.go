@@ -0,0 +1,205 @@
+package credentials
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const fileFormatVersion = 1
+
+// encryptedFile is the on-disk layout of credentials.enc
+type encryptedFile struct {
+	Version int    `json:"version"`
+	Salt    []byte `json:"salt"`
+	Nonce   []byte `json:"nonce"`
+	Data    []byte `json:"data"`
+}
+
+// fileStore keeps secrets in a passphrase-encrypted (scrypt + AES-GCM) file with 0600 permissions.
+// The passphrase is only requested on first use and the decrypted secrets are kept in memory.
+type fileStore struct {
+	path       string
+	passphrase PassphraseFunc
+
+	mu      sync.Mutex
+	loaded  bool
+	key     []byte
+	salt    []byte
+	secrets map[string]string
+}
+
+func newFileStore(path string, passphrase PassphraseFunc) *fileStore {
+	return &fileStore{path: path, passphrase: passphrase}
+}
+
+func (s *fileStore) Get(key string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.load(); err != nil {
+		return "", err
+	}
+	value, ok := s.secrets[key]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return value, nil
+}
+
+func (s *fileStore) Set(key, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.load(); err != nil {
+		return err
+	}
+	s.secrets[key] = value
+	return s.save()
+}
+
+func (s *fileStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.load(); err != nil {
+		return err
+	}
+	if _, ok := s.secrets[key]; !ok {
+		return nil
+	}
+	delete(s.secrets, key)
+	return s.save()
+}
+
+func (s *fileStore) Name() string {
+	return BackendFile
+}
+
+// load reads and decrypts the file once; a missing file starts an empty store
+func (s *fileStore) load() error {
+	if s.loaded {
+		return nil
+	}
+	if s.passphrase == nil {
+		return errors.New("no passphrase available for the encrypted credentials file")
+	}
+
+	data, err := os.ReadFile(s.path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read credentials file: %w", err)
+	}
+
+	passphrase, err := s.passphrase()
+	if err != nil {
+		return fmt.Errorf("failed to get credentials passphrase: %w", err)
+	}
+
+	secrets := make(map[string]string)
+	if data == nil {
+		s.salt = make([]byte, 16)
+		if _, err := rand.Read(s.salt); err != nil {
+			return err
+		}
+		if s.key, err = deriveKey(passphrase, s.salt); err != nil {
+			return err
+		}
+	} else {
+		var file encryptedFile
+		if err := json.Unmarshal(data, &file); err != nil {
+			return fmt.Errorf("failed to parse credentials file: %w", err)
+		}
+		if file.Version != fileFormatVersion {
+			return fmt.Errorf("unsupported credentials file version %d", file.Version)
+		}
+		if s.key, err = deriveKey(passphrase, file.Salt); err != nil {
+			return err
+		}
+		gcm, err := newGCM(s.key)
+		if err != nil {
+			return err
+		}
+		plaintext, err := gcm.Open(nil, file.Nonce, file.Data, nil)
+		if err != nil {
+			return errors.New("failed to decrypt credentials file: wrong passphrase or corrupted file")
+		}
+		if err := json.Unmarshal(plaintext, &secrets); err != nil {
+			return fmt.Errorf("failed to parse decrypted credentials: %w", err)
+		}
+		s.salt = file.Salt
+	}
+
+	s.secrets = secrets
+	s.loaded = true
+	return nil
+}
+
+// save encrypts the secrets with a fresh nonce and replaces the file atomically
+func (s *fileStore) save() error {
+	plaintext, err := json.Marshal(s.secrets)
+	if err != nil {
+		return err
+	}
+
+	gcm, err := newGCM(s.key)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(encryptedFile{
+		Version: fileFormatVersion,
+		Salt:    s.salt,
+		Nonce:   nonce,
+		Data:    gcm.Seal(nil, nonce, plaintext, nil),
+	})
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, ".credentials-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+func deriveKey(passphrase string, salt []byte) ([]byte, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, 1<<15, 8, 1, 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive credentials key: %w", err)
+	}
+	return key, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,74 @@
+package credentials
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/Banh-Canh/ytui/internal/utils"
+)
+
+func TestMain(m *testing.M) {
+	// Mock zap.Logger to avoid breaking tests.
+	utils.Logger = zap.NewNop()
+
+	// Run the tests
+	m.Run()
+}
+
+func staticPassphrase(passphrase string) PassphraseFunc {
+	return func() (string, error) { return passphrase, nil }
+}
+
+func TestFileStore_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), encryptedFileName)
+
+	store := newFileStore(path, staticPassphrase("correct horse"))
+	require.NoError(t, store.Set(TokenKey, `{"access_token":"abc"}`))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "access_token", "secrets must not be stored in clear")
+
+	reopened := newFileStore(path, staticPassphrase("correct horse"))
+	value, err := reopened.Get(TokenKey)
+	require.NoError(t, err)
+	assert.Equal(t, `{"access_token":"abc"}`, value)
+
+	require.NoError(t, reopened.Delete(TokenKey))
+	_, err = reopened.Get(TokenKey)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestFileStore_WrongPassphrase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), encryptedFileName)
+	require.NoError(t, newFileStore(path, staticPassphrase("right")).Set(ClientSecretKey, "secret"))
+
+	_, err := newFileStore(path, staticPassphrase("wrong")).Get(ClientSecretKey)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "wrong passphrase")
+}
+
+func TestMigratePlaintextToken(t *testing.T) {
+	configDir := t.TempDir()
+	legacyPath := filepath.Join(configDir, plaintextTokenFile)
+	require.NoError(t, os.WriteFile(legacyPath, []byte(`{"access_token":"legacy"}`+"\n"), 0o644))
+
+	store := newFileStore(filepath.Join(configDir, encryptedFileName), staticPassphrase("pass"))
+	require.NoError(t, MigratePlaintextToken(store, configDir))
+
+	_, err := os.Stat(legacyPath)
+	assert.True(t, os.IsNotExist(err), "plaintext token should be deleted")
+
+	token, err := TokenStore{Store: store}.LoadToken()
+	require.NoError(t, err)
+	assert.Equal(t, "legacy", token.AccessToken)
+}
@@ -0,0 +1,58 @@
+package credentials
+
+import (
+	"errors"
+
+	"github.com/zalando/go-keyring"
+
+	"github.com/Banh-Canh/ytui/internal/config"
+)
+
+const keyringService = "ytui"
+
+// keyringStore stores secrets in the OS keyring (Secret Service, Keychain or Credential Manager)
+type keyringStore struct {
+	service string
+}
+
+// newKeyringStore namespaces entries per profile; the default profile keeps the plain service name
+func newKeyringStore(profile string) *keyringStore {
+	service := keyringService
+	if profile != "" && profile != config.DefaultProfile {
+		service = keyringService + ":" + profile
+	}
+	return &keyringStore{service: service}
+}
+
+// probeKeyring checks that the keyring answers; a missing entry still counts as reachable
+func probeKeyring() error {
+	_, err := keyring.Get(keyringService, "probe")
+	if err == nil || errors.Is(err, keyring.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+func (s *keyringStore) Get(key string) (string, error) {
+	value, err := keyring.Get(s.service, key)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", ErrNotFound
+	}
+	return value, err
+}
+
+func (s *keyringStore) Set(key, value string) error {
+	return keyring.Set(s.service, key, value)
+}
+
+func (s *keyringStore) Delete(key string) error {
+	err := keyring.Delete(s.service, key)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+func (s *keyringStore) Name() string {
+	return BackendKeyring
+}
@@ -0,0 +1,49 @@
+package credentials
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"go.uber.org/zap"
+
+	"github.com/Banh-Canh/ytui/internal/utils"
+)
+
+// plaintextTokenFile is where tokens were written before the credential store existed
+const plaintextTokenFile = "credentials.json"
+
+// MigratePlaintextToken moves the legacy credentials.json token into the store and deletes the file
+func MigratePlaintextToken(store Store, configDir string) error {
+	path := filepath.Join(configDir, plaintextTokenFile)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read plaintext token: %w", err)
+	}
+
+	if token := bytes.TrimSpace(data); len(token) > 0 {
+		if err := store.Set(TokenKey, string(token)); err != nil {
+			return fmt.Errorf("failed to store token: %w", err)
+		}
+	}
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("failed to remove plaintext token: %w", err)
+	}
+
+	utils.Logger.Info("Migrated plaintext OAuth2 token to credential store.", zap.String("backend", store.Name()))
+	return nil
+}
+
+// MigrateClientSecret stores a client secret found in plaintext config; the caller blanks the config value
+func MigrateClientSecret(store Store, secret string) error {
+	if err := store.Set(ClientSecretKey, secret); err != nil {
+		return fmt.Errorf("failed to store client secret: %w", err)
+	}
+	utils.Logger.Info("Migrated client secret from config to credential store.", zap.String("backend", store.Name()))
+	return nil
+}
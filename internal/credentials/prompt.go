@@ -0,0 +1,35 @@
+package credentials
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/term"
+)
+
+// PassphraseEnv lets scripts unlock the encrypted credentials file without a prompt
+const PassphraseEnv = "YTUI_CREDENTIALS_PASSPHRASE"
+
+// PromptPassphrase reads the passphrase from PassphraseEnv or asks for it on the terminal
+func PromptPassphrase() (string, error) {
+	if passphrase := os.Getenv(PassphraseEnv); passphrase != "" {
+		return passphrase, nil
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", fmt.Errorf("no terminal to prompt for the credentials passphrase, set %s", PassphraseEnv)
+	}
+
+	fmt.Fprint(os.Stderr, "ytui credentials passphrase: ")
+	passphrase, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", err
+	}
+	if len(passphrase) == 0 {
+		return "", errors.New("empty passphrase")
+	}
+	return string(passphrase), nil
+}
@@ -0,0 +1,105 @@
+package credentials
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+
+	"github.com/Banh-Canh/ytui/internal/config"
+	"github.com/Banh-Canh/ytui/internal/utils"
+)
+
+const (
+	// TokenKey holds the OAuth2 token as JSON
+	TokenKey = "oauth2-token"
+	// ClientSecretKey holds the Google OAuth client secret
+	ClientSecretKey = "youtube-client-secret"
+
+	// Backend names accepted by the credentials.backend setting
+	BackendAuto    = "auto"
+	BackendKeyring = "keyring"
+	BackendFile    = "file"
+
+	encryptedFileName = "credentials.enc"
+)
+
+// ErrNotFound is returned when a secret is not in the store
+var ErrNotFound = errors.New("secret not found")
+
+// Store keeps small secrets such as tokens and client secrets
+type Store interface {
+	Get(key string) (string, error)
+	Set(key, value string) error
+	Delete(key string) error
+	// Name identifies the backend in logs and messages
+	Name() string
+}
+
+// PassphraseFunc supplies the passphrase for the encrypted file backend
+type PassphraseFunc func() (string, error)
+
+// Open returns the store for the requested backend. With BackendAuto the OS
+// keyring is used when reachable and the encrypted file in configDir otherwise.
+// Keyring entries are namespaced by profile, the file lives in the profile directory.
+func Open(backend, configDir, profile string, passphrase PassphraseFunc) (Store, error) {
+	filePath := filepath.Join(configDir, encryptedFileName)
+
+	switch backend {
+	case BackendKeyring:
+		if err := probeKeyring(); err != nil {
+			return nil, fmt.Errorf("keyring unavailable: %w", err)
+		}
+		return newKeyringStore(profile), nil
+	case BackendFile:
+		return newFileStore(filePath, passphrase), nil
+	case BackendAuto, "":
+		if err := probeKeyring(); err != nil {
+			utils.Logger.Info("Keyring unavailable, using encrypted credentials file.", zap.Error(err))
+			return newFileStore(filePath, passphrase), nil
+		}
+		return newKeyringStore(profile), nil
+	default:
+		return nil, fmt.Errorf("unknown credentials backend %q", backend)
+	}
+}
+
+// OpenConfigured opens the store configured for the active profile and moves any
+// plaintext secrets (legacy token file, client secret in config.yaml) into it
+func OpenConfigured(passphrase PassphraseFunc) (Store, error) {
+	configDir, err := config.GetConfigDirPath()
+	if err != nil {
+		return nil, err
+	}
+
+	store, err := Open(viper.GetString("credentials.backend"), configDir, config.ActiveProfile(), passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open credential store: %w", err)
+	}
+
+	if err := MigratePlaintextToken(store, configDir); err != nil {
+		return nil, err
+	}
+
+	if secret := viper.GetString("youtube.secretid"); secret != "" && secret != config.PlaceholderSecret {
+		if err := MigrateClientSecret(store, secret); err != nil {
+			return nil, err
+		}
+		viper.Set("youtube.secretid", "")
+		if err := viper.WriteConfig(); err != nil {
+			utils.Logger.Error("Failed to remove client secret from config.", zap.Error(err))
+		}
+	}
+
+	return store, nil
+}
+
+// Unlock reads the store once so that a passphrase prompt happens while the caller still owns the terminal
+func Unlock(store Store) error {
+	if _, err := store.Get(TokenKey); err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	return nil
+}
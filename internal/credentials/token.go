@@ -0,0 +1,43 @@
+package credentials
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/oauth2"
+
+	"github.com/Banh-Canh/ytui/pkg/youtube"
+)
+
+// TokenStore adapts a Store to youtube.TokenStore
+type TokenStore struct {
+	Store Store
+}
+
+func (t TokenStore) LoadToken() (*oauth2.Token, error) {
+	value, err := t.Store.Get(TokenKey)
+	if errors.Is(err, ErrNotFound) {
+		return nil, youtube.ErrNoToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := youtube.UnmarshalToken([]byte(value))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse stored token: %w", err)
+	}
+	return token, nil
+}
+
+func (t TokenStore) SaveToken(token *oauth2.Token) error {
+	data, err := youtube.MarshalToken(token)
+	if err != nil {
+		return err
+	}
+	return t.Store.Set(TokenKey, string(data))
+}
+
+func (t TokenStore) DeleteToken() error {
+	return t.Store.Delete(TokenKey)
+}
@@ -0,0 +1,192 @@
+// Package dearrow looks up crowd-sourced replacement titles and thumbnails of YouTube videos.
+package dearrow
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultServerURL is the public DeArrow server
+const DefaultServerURL = "https://sponsor.ajay.app"
+
+// hashPrefixLength is how many hex characters of the video ID hash are sent to the server
+const hashPrefixLength = 4
+
+// maxConcurrentRequests bounds the lookups running at once for a batch
+const maxConcurrentRequests = 4
+
+// Branding is the crowd-sourced branding of a video. Empty fields mean nothing better than
+// the original was submitted.
+type Branding struct {
+	Title string
+	// ThumbnailTime is the timestamp, in seconds, of the frame voted as thumbnail
+	ThumbnailTime float64
+	HasThumbnail  bool
+}
+
+// Client looks up branding and caches every answer for the lifetime of the client
+type Client struct {
+	serverURL  string
+	httpClient *http.Client
+
+	mu    sync.Mutex
+	cache map[string]Branding
+}
+
+// NewClient returns a client for the given server, DefaultServerURL when empty
+func NewClient(serverURL string) *Client {
+	if serverURL == "" {
+		serverURL = DefaultServerURL
+	}
+	return &Client{
+		serverURL:  strings.TrimRight(serverURL, "/"),
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		cache:      make(map[string]Branding),
+	}
+}
+
+type brandingResponse map[string]struct {
+	Titles []struct {
+		Title    string `json:"title"`
+		Original bool   `json:"original"`
+		Votes    int    `json:"votes"`
+		Locked   bool   `json:"locked"`
+	} `json:"titles"`
+	Thumbnails []struct {
+		Timestamp *float64 `json:"timestamp"`
+		Original  bool     `json:"original"`
+		Votes     int      `json:"votes"`
+		Locked    bool     `json:"locked"`
+	} `json:"thumbnails"`
+}
+
+// Branding returns the branding of the given videos. Videos sharing a hash prefix are
+// looked up with a single request and cached videos are not requested again. Lookup
+// errors are returned along with whatever could be fetched.
+func (c *Client) Branding(ctx context.Context, videoIDs []string) (map[string]Branding, error) {
+	result := make(map[string]Branding, len(videoIDs))
+	prefixes := make(map[string][]string)
+
+	c.mu.Lock()
+	for _, videoID := range videoIDs {
+		if branding, ok := c.cache[videoID]; ok {
+			result[videoID] = branding
+		} else {
+			prefix := hashPrefix(videoID)
+			prefixes[prefix] = append(prefixes[prefix], videoID)
+		}
+	}
+	c.mu.Unlock()
+
+	var (
+		wg       sync.WaitGroup
+		errMu    sync.Mutex
+		firstErr error
+		slots    = make(chan struct{}, maxConcurrentRequests)
+	)
+	for prefix, requested := range prefixes {
+		wg.Add(1)
+		go func(prefix string, requested []string) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			if err := c.fetchPrefix(ctx, prefix, requested); err != nil {
+				errMu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				errMu.Unlock()
+			}
+		}(prefix, requested)
+	}
+	wg.Wait()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, videoID := range videoIDs {
+		if branding, ok := c.cache[videoID]; ok {
+			result[videoID] = branding
+		}
+	}
+	return result, firstErr
+}
+
+// fetchPrefix caches the branding of every video matching the hash prefix. Requested
+// videos missing from the answer are cached as having no branding.
+func (c *Client) fetchPrefix(ctx context.Context, prefix string, requested []string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/api/branding/%s", c.serverURL, prefix), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to query DeArrow: %w", err)
+	}
+	defer resp.Body.Close()
+
+	response := brandingResponse{}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+			return fmt.Errorf("failed to decode DeArrow response: %w", err)
+		}
+	case http.StatusNotFound:
+		// Nothing submitted for any video with this prefix
+	default:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("DeArrow returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, videoID := range requested {
+		c.cache[videoID] = Branding{}
+	}
+	for videoID, video := range response {
+		var branding Branding
+		for _, title := range video.Titles {
+			// Titles come best first; an original one means the real title won
+			if title.Votes < 0 && !title.Locked {
+				continue
+			}
+			if !title.Original {
+				branding.Title = cleanTitle(title.Title)
+			}
+			break
+		}
+		for _, thumbnail := range video.Thumbnails {
+			if thumbnail.Votes < 0 && !thumbnail.Locked {
+				continue
+			}
+			if !thumbnail.Original && thumbnail.Timestamp != nil {
+				branding.ThumbnailTime = *thumbnail.Timestamp
+				branding.HasThumbnail = true
+			}
+			break
+		}
+		c.cache[videoID] = branding
+	}
+	return nil
+}
+
+// formatMarker matches the '>' DeArrow puts before words that must keep their case
+var formatMarker = regexp.MustCompile(`(^|\s)>(\S)`)
+
+func cleanTitle(title string) string {
+	return strings.TrimSpace(formatMarker.ReplaceAllString(title, "$1$2"))
+}
+
+func hashPrefix(videoID string) string {
+	sum := sha256.Sum256([]byte(videoID))
+	return hex.EncodeToString(sum[:])[:hashPrefixLength]
+}
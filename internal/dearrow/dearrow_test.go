@@ -0,0 +1,84 @@
+package dearrow
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBranding_ParsesAndCaches(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path != "/api/branding/"+hashPrefix("video1") {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"video1": {
+				"titles": [{"title": "A >iPhone review", "original": false, "votes": 3, "locked": false}],
+				"thumbnails": [{"timestamp": 42.5, "original": false, "votes": 1, "locked": false}]
+			},
+			"neighbour": {
+				"titles": [{"title": "Real title", "original": true, "votes": 5, "locked": false}],
+				"thumbnails": []
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	branding, err := client.Branding(context.Background(), []string{"video1", "video2"})
+	require.NoError(t, err)
+
+	assert.Equal(t, Branding{Title: "A iPhone review", ThumbnailTime: 42.5, HasThumbnail: true}, branding["video1"])
+	assert.Equal(t, Branding{}, branding["video2"], "a video without submissions has no branding")
+	assert.Equal(t, int32(2), requests.Load())
+
+	// Everything is answered from the cache now, including the other video of the prefix
+	branding, err = client.Branding(context.Background(), []string{"video1", "video2", "neighbour"})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), requests.Load())
+	assert.Equal(t, Branding{}, branding["neighbour"], "an original title is not a replacement")
+}
+
+func TestBranding_SkipsDownvotedTitles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"video1": {"titles": [
+			{"title": "Downvoted", "original": false, "votes": -1, "locked": false},
+			{"title": "Better", "original": false, "votes": 0, "locked": false}
+		]}}`))
+	}))
+	defer server.Close()
+
+	branding, err := NewClient(server.URL).Branding(context.Background(), []string{"video1"})
+	require.NoError(t, err)
+	assert.Equal(t, "Better", branding["video1"].Title)
+}
+
+func TestBranding_ServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "down", http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	_, err := client.Branding(context.Background(), []string{"video1"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "502")
+
+	// Failed lookups are not cached and can be retried
+	_, err = client.Branding(context.Background(), []string{"video1"})
+	require.Error(t, err)
+}
+
+func TestCleanTitle(t *testing.T) {
+	assert.Equal(t, "How NASA builds rockets", cleanTitle(">How >NASA builds rockets"))
+	assert.Equal(t, "5 > 3", cleanTitle("5 > 3"))
+}
@@ -0,0 +1,22 @@
+package format
+
+import "strings"
+
+// barEighths are the partial blocks of a bar, by eighths of a cell
+var barEighths = []string{"", "▏", "▎", "▍", "▌", "▋", "▊", "▉"}
+
+// Bar renders value as a horizontal bar of up to width cells, max filling them all.
+// Bars are drawn to an eighth of a cell and any value above zero shows.
+func Bar(value, max float64, width int) string {
+	if value <= 0 || max <= 0 || width <= 0 {
+		return ""
+	}
+	if value > max {
+		value = max
+	}
+	eighths := int(value / max * float64(width*8))
+	if eighths == 0 {
+		eighths = 1
+	}
+	return strings.Repeat("█", eighths/8) + barEighths[eighths%8]
+}
@@ -0,0 +1,174 @@
+// Package format renders durations, dates and counts the same way in every view.
+package format
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"time"
+)
+
+// Duration renders a video length clock-style: 4:05, 1:02:03. Zero renders as an empty string.
+func Duration(d time.Duration) string {
+	if d <= 0 {
+		return ""
+	}
+	seconds := int64(d.Round(time.Second) / time.Second)
+	hours, minutes := seconds/3600, seconds%3600/60
+	seconds %= 60
+	if hours > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", hours, minutes, seconds)
+	}
+	return fmt.Sprintf("%d:%02d", minutes, seconds)
+}
+
+// Countdown keeps the two largest units, such as 2d 5h or 3h 12m
+func Countdown(d time.Duration) string {
+	d = d.Round(time.Minute)
+	days := int(d / (24 * time.Hour))
+	hours := int(d % (24 * time.Hour) / time.Hour)
+	minutes := int(d % time.Hour / time.Minute)
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	}
+	return fmt.Sprintf("%dm", minutes)
+}
+
+// Date renders the calendar day of t, empty for the zero time
+func Date(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Local().Format("2006-01-02")
+}
+
+// ViewCount renders a compact view count: 999, 1.2K, 34K, 5.6M, 1.2B
+func ViewCount(views int64) string {
+	units := []struct {
+		size   float64
+		suffix string
+	}{{1e9, "B"}, {1e6, "M"}, {1e3, "K"}}
+
+	for _, unit := range units {
+		if float64(views) < unit.size {
+			continue
+		}
+		value := float64(views) / unit.size
+		if value < 10 {
+			// Truncate rather than round so that 1999 never shows as 2K
+			return strings.TrimSuffix(fmt.Sprintf("%.1f", math.Floor(value*10)/10), ".0") + unit.suffix
+		}
+		return fmt.Sprintf("%d%s", int64(value), unit.suffix)
+	}
+	return fmt.Sprintf("%d", views)
+}
+
+// Relative renders t relative to now in the user's locale, such as "3 days ago" or "in 2
+// hours". The zero time renders as an empty string.
+func Relative(t, now time.Time) string {
+	return RelativeIn(CurrentLocale(), t, now)
+}
+
+// RelativeIn is Relative for a given locale
+func RelativeIn(locale Locale, t, now time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	words, ok := relativeWords[locale]
+	if !ok {
+		words = relativeWords[English]
+	}
+
+	d := now.Sub(t)
+	future := d < 0
+	if future {
+		d = -d
+	}
+	if d < time.Minute {
+		return words.now
+	}
+
+	count, unit := relativeUnit(d)
+	name := words.units[unit][0]
+	if count != 1 {
+		name = words.units[unit][1]
+	}
+	amount := fmt.Sprintf("%d %s", count, name)
+	if future {
+		return fmt.Sprintf(words.future, amount)
+	}
+	return fmt.Sprintf(words.past, amount)
+}
+
+// relativeUnit picks the largest unit of which d holds at least one
+func relativeUnit(d time.Duration) (int64, int) {
+	const day = 24 * time.Hour
+	steps := []time.Duration{365 * day, 30 * day, 7 * day, day, time.Hour, time.Minute}
+	for unit, step := range steps {
+		if d >= step {
+			return int64(d / step), unit
+		}
+	}
+	return 0, len(steps) - 1
+}
+
+// Locale selects the language of relative times
+type Locale string
+
+// Supported locales; any other falls back to English
+const (
+	English Locale = "en"
+	French  Locale = "fr"
+	German  Locale = "de"
+	Spanish Locale = "es"
+)
+
+// relativeLocale holds the words of relative times: past and future wrap the amount,
+// units are singular/plural pairs for years, months, weeks, days, hours and minutes
+type relativeLocale struct {
+	now    string
+	past   string
+	future string
+	units  [6][2]string
+}
+
+var relativeWords = map[Locale]relativeLocale{
+	English: {"just now", "%s ago", "in %s", [6][2]string{
+		{"year", "years"}, {"month", "months"}, {"week", "weeks"}, {"day", "days"}, {"hour", "hours"}, {"minute", "minutes"},
+	}},
+	French: {"à l'instant", "il y a %s", "dans %s", [6][2]string{
+		{"an", "ans"}, {"mois", "mois"}, {"semaine", "semaines"}, {"jour", "jours"}, {"heure", "heures"}, {"minute", "minutes"},
+	}},
+	German: {"gerade eben", "vor %s", "in %s", [6][2]string{
+		{"Jahr", "Jahren"}, {"Monat", "Monaten"}, {"Woche", "Wochen"}, {"Tag", "Tagen"}, {"Stunde", "Stunden"}, {"Minute", "Minuten"},
+	}},
+	Spanish: {"ahora mismo", "hace %s", "en %s", [6][2]string{
+		{"año", "años"}, {"mes", "meses"}, {"semana", "semanas"}, {"día", "días"}, {"hora", "horas"}, {"minuto", "minutos"},
+	}},
+}
+
+// CurrentLocale reads the language from LC_ALL, LC_MESSAGES or LANG, like gettext does
+func CurrentLocale() Locale {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := os.Getenv(name); value != "" {
+			return parseLocale(value)
+		}
+	}
+	return English
+}
+
+// parseLocale keeps the language of values such as fr_FR.UTF-8
+func parseLocale(value string) Locale {
+	language := strings.ToLower(value)
+	if i := strings.IndexAny(language, "_.@-"); i >= 0 {
+		language = language[:i]
+	}
+	if _, ok := relativeWords[Locale(language)]; ok {
+		return Locale(language)
+	}
+	return English
+}
@@ -0,0 +1,71 @@
+package format
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDuration(t *testing.T) {
+	assert.Equal(t, "", Duration(0))
+	assert.Equal(t, "0:07", Duration(7*time.Second))
+	assert.Equal(t, "4:05", Duration(4*time.Minute+5*time.Second))
+	assert.Equal(t, "1:02:03", Duration(time.Hour+2*time.Minute+3*time.Second))
+	assert.Equal(t, "12:00:00", Duration(12*time.Hour))
+}
+
+func TestCountdown(t *testing.T) {
+	assert.Equal(t, "3h 12m", Countdown(3*time.Hour+12*time.Minute+10*time.Second))
+	assert.Equal(t, "45m", Countdown(45*time.Minute))
+	assert.Equal(t, "2d 0h", Countdown(48*time.Hour))
+	assert.Equal(t, "0m", Countdown(20*time.Second))
+}
+
+func TestViewCount(t *testing.T) {
+	tests := map[int64]string{
+		0:             "0",
+		999:           "999",
+		1000:          "1K",
+		1999:          "1.9K",
+		34567:         "34K",
+		5_600_000:     "5.6M",
+		123_456_789:   "123M",
+		1_200_000_000: "1.2B",
+	}
+	for views, want := range tests {
+		assert.Equal(t, want, ViewCount(views), "%d views", views)
+	}
+}
+
+func TestRelativeIn(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "", RelativeIn(English, time.Time{}, now))
+	assert.Equal(t, "just now", RelativeIn(English, now.Add(-30*time.Second), now))
+	assert.Equal(t, "1 minute ago", RelativeIn(English, now.Add(-time.Minute), now))
+	assert.Equal(t, "3 days ago", RelativeIn(English, now.Add(-75*time.Hour), now))
+	assert.Equal(t, "2 weeks ago", RelativeIn(English, now.AddDate(0, 0, -15), now))
+	assert.Equal(t, "1 year ago", RelativeIn(English, now.AddDate(-1, 0, -1), now))
+	assert.Equal(t, "in 2 hours", RelativeIn(English, now.Add(2*time.Hour), now))
+	assert.Equal(t, "il y a 3 jours", RelativeIn(French, now.Add(-75*time.Hour), now))
+	assert.Equal(t, "vor 1 Monat", RelativeIn(German, now.AddDate(0, -1, -1), now))
+	assert.Equal(t, "3 days ago", RelativeIn(Locale("xx"), now.Add(-75*time.Hour), now))
+}
+
+func TestParseLocale(t *testing.T) {
+	assert.Equal(t, French, parseLocale("fr_FR.UTF-8"))
+	assert.Equal(t, Spanish, parseLocale("es"))
+	assert.Equal(t, English, parseLocale("C"))
+	assert.Equal(t, English, parseLocale("ja_JP.UTF-8"))
+}
+
+func TestBar(t *testing.T) {
+	assert.Equal(t, "██████████", Bar(10, 10, 10))
+	assert.Equal(t, "█████", Bar(5, 10, 10))
+	assert.Equal(t, "█▌", Bar(3, 20, 10))
+	assert.Equal(t, "▏", Bar(0.01, 10, 10), "small values still show")
+	assert.Equal(t, "██████████", Bar(20, 10, 10), "values are capped")
+	assert.Empty(t, Bar(0, 10, 10))
+	assert.Empty(t, Bar(5, 0, 10))
+}
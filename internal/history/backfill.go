@@ -0,0 +1,117 @@
+package history
+
+import (
+	"encoding/json"
+
+	bolt "go.etcd.io/bbolt"
+	"go.uber.org/zap"
+
+	"github.com/Banh-Canh/ytui/internal/utils"
+	"github.com/Banh-Canh/ytui/pkg/youtube"
+)
+
+// backfillChunk is how many videos Backfill fetches between two saves, so that an
+// interrupted backfill keeps what it fetched and the database is not held meanwhile
+const backfillChunk = 50
+
+// FetchFunc looks up the metadata of videos, returning those it could fetch even when
+// others failed, such as SearchService.VideoInfoBatch
+type FetchFunc func(videoIDs []string) (map[string]youtube.SearchResultItem, error)
+
+// MissingMetadata returns the IDs of the watched videos without length or thumbnails,
+// such as those imported from Takeout, last watched first
+func (s *Store) MissingMetadata() ([]string, error) {
+	entries, err := s.Recent(0)
+	if err != nil {
+		return nil, err
+	}
+	var videoIDs []string
+	for _, entry := range entries {
+		if entry.Video.LengthSeconds == 0 || len(entry.Video.VideoThumbnails) == 0 {
+			videoIDs = append(videoIDs, entry.Video.VideoID)
+		}
+	}
+	return videoIDs, nil
+}
+
+// UpdateMetadata replaces the metadata of watched videos with fetched ones, keeping
+// their play statistics. Videos missing from the history are ignored.
+func (s *Store) UpdateMetadata(videos map[string]youtube.SearchResultItem) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(videosBucket)
+		for videoID, fetched := range videos {
+			entry, found := getEntry(bucket, videoID)
+			if !found {
+				continue
+			}
+			// Keep what the lookup doesn't know
+			fetched.VideoID = videoID
+			fetched.ViewedDate = entry.Video.ViewedDate
+			if fetched.Title == "" {
+				fetched.Title = entry.Video.Title
+			}
+			if fetched.AuthorID == "" {
+				fetched.Author, fetched.AuthorID, fetched.AuthorURL = entry.Video.Author, entry.Video.AuthorID, entry.Video.AuthorURL
+			}
+			if fetched.AuthorID != entry.Video.AuthorID {
+				if err := tx.Bucket(channelIndex).Delete(channelKey(entry.Video.AuthorID, videoID)); err != nil {
+					return err
+				}
+				if err := tx.Bucket(channelIndex).Put(channelKey(fetched.AuthorID, videoID), nil); err != nil {
+					return err
+				}
+			}
+			entry.Video = fetched
+
+			data, err := json.Marshal(entry)
+			if err != nil {
+				return err
+			}
+			if err := bucket.Put([]byte(videoID), data); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Backfill fetches the metadata of the watched videos of a config directory that lack
+// it, backfillChunk at a time. progress, if set, is called after each chunk. It returns
+// how many videos were updated; videos that could not be fetched are logged and skipped.
+func Backfill(fetch FetchFunc, progress func(done, total int), configDir string) (int, error) {
+	store, err := OpenInDir(configDir)
+	if err != nil {
+		return 0, err
+	}
+	videoIDs, err := store.MissingMetadata()
+	store.Close()
+	if err != nil {
+		return 0, err
+	}
+
+	updated := 0
+	for start := 0; start < len(videoIDs); start += backfillChunk {
+		chunk := videoIDs[start:min(start+backfillChunk, len(videoIDs))]
+		videos, err := fetch(chunk)
+		if err != nil {
+			utils.Logger.Warn("Some videos could not be backfilled.", zap.Error(err))
+		}
+
+		if len(videos) > 0 {
+			store, err := OpenInDir(configDir)
+			if err != nil {
+				return updated, err
+			}
+			err = store.UpdateMetadata(videos)
+			store.Close()
+			if err != nil {
+				return updated, err
+			}
+			updated += len(videos)
+		}
+		if progress != nil {
+			progress(start+len(chunk), len(videoIDs))
+		}
+	}
+	return updated, nil
+}
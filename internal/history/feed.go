@@ -0,0 +1,75 @@
+package history
+
+import (
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+	"go.uber.org/zap"
+
+	"github.com/Banh-Canh/ytui/internal/utils"
+)
+
+// LastSeen returns when the videos of channels were last seen in the subscriptions
+// feed. Channels never seen are missing.
+func (s *Store) LastSeen(channelIDs ...string) (map[string]time.Time, error) {
+	seen := make(map[string]time.Time, len(channelIDs))
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(feedBucket)
+		for _, channelID := range channelIDs {
+			if value := bucket.Get([]byte(channelID)); len(value) == 8 {
+				seen[channelID] = keyTime(value)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return seen, nil
+}
+
+// MarkSeen records that the videos of channels published until at were seen. It never
+// moves a channel's last visit back.
+func (s *Store) MarkSeen(at time.Time, channelIDs ...string) error {
+	value := timePrefix(at)
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(feedBucket)
+		for _, channelID := range channelIDs {
+			if channelID == "" {
+				continue
+			}
+			if previous := bucket.Get([]byte(channelID)); len(previous) == 8 && !keyTime(previous).Before(at) {
+				continue
+			}
+			if err := bucket.Put([]byte(channelID), value); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// LastSeen returns when the videos of channels were last seen in the subscriptions feed
+// of a config directory
+func LastSeen(channelIDs []string, configDir string) (map[string]time.Time, error) {
+	return fromStore(configDir, func(store *Store) (map[string]time.Time, error) {
+		seen, err := store.LastSeen(channelIDs...)
+		if err != nil {
+			utils.Logger.Error("Failed to read the last feed visit.", zap.Error(err))
+			return nil, err
+		}
+		return seen, nil
+	})
+}
+
+// MarkSeen records that the videos of channels of a config directory published until at
+// were seen
+func MarkSeen(at time.Time, channelIDs []string, configDir string) error {
+	return withStore(configDir, func(store *Store) error {
+		if err := store.MarkSeen(at, channelIDs...); err != nil {
+			utils.Logger.Error("Failed to record the feed visit.", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+}
@@ -0,0 +1,50 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_MarkSeen(t *testing.T) {
+	store := openTestStore(t)
+	day := time.Date(2025, 3, 1, 20, 0, 0, 0, time.UTC)
+
+	seen, err := store.LastSeen("UC1", "UC2")
+	require.NoError(t, err)
+	assert.Empty(t, seen, "never visited")
+
+	require.NoError(t, store.MarkSeen(day, "UC1", "UC2", ""))
+	require.NoError(t, store.MarkSeen(day.Add(-time.Hour), "UC1"))
+	require.NoError(t, store.MarkSeen(day.Add(time.Hour), "UC2"))
+	seen, err = store.LastSeen("UC1", "UC2", "UC3")
+	require.NoError(t, err)
+	assert.Len(t, seen, 2)
+	assert.True(t, seen["UC1"].Equal(day), "never moves back")
+	assert.True(t, seen["UC2"].Equal(day.Add(time.Hour)))
+
+	require.NoError(t, store.Clear())
+	seen, err = store.LastSeen("UC1")
+	require.NoError(t, err)
+	assert.Len(t, seen, 1, "clearing the watch history keeps the feed visits")
+}
+
+func TestLastSeen(t *testing.T) {
+	dir := t.TempDir()
+	day := time.Date(2025, 3, 1, 20, 0, 0, 0, time.UTC)
+
+	seen, err := LastSeen([]string{"UC1"}, dir)
+	require.NoError(t, err)
+	assert.Empty(t, seen)
+	seen, err = LastSeen([]string{"UC1"}, dir)
+	require.NoError(t, err)
+	assert.Empty(t, seen, "reading is not a visit")
+
+	require.NoError(t, MarkSeen(day, []string{"UC1"}, dir))
+	seen, err = LastSeen([]string{"UC1", "UC2"}, dir)
+	require.NoError(t, err)
+	assert.True(t, seen["UC1"].Equal(day))
+	assert.NotContains(t, seen, "UC2")
+}
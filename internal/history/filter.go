@@ -0,0 +1,60 @@
+package history
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Banh-Canh/ytui/pkg/youtube"
+)
+
+// Filter selects watched videos. Its zero value matches everything.
+type Filter struct {
+	// Query matches the title or the channel name, case-insensitively
+	Query string
+	// Channel matches the channel ID, or the channel name case-insensitively
+	Channel string
+	// From and To bound the last play to [From, To); zero times leave the range open
+	From, To time.Time
+}
+
+// IsZero reports whether the filter matches everything
+func (f Filter) IsZero() bool {
+	return f.Query == "" && f.Channel == "" && f.From.IsZero() && f.To.IsZero()
+}
+
+// Match reports whether a watched video, with ViewedDate set to its last play, passes
+// the filter
+func (f Filter) Match(video youtube.SearchResultItem) bool {
+	if f.Query != "" {
+		query := strings.ToLower(f.Query)
+		if !strings.Contains(strings.ToLower(video.Title), query) && !strings.Contains(strings.ToLower(video.Author), query) {
+			return false
+		}
+	}
+	if f.Channel != "" && video.AuthorID != f.Channel && !strings.EqualFold(video.Author, f.Channel) {
+		return false
+	}
+	viewed := video.ViewedAt()
+	if !f.From.IsZero() && viewed.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && !viewed.Before(f.To) {
+		return false
+	}
+	return true
+}
+
+// Find returns the watched videos passing the filter, last watched first
+func (s *Store) Find(filter Filter) ([]Entry, error) {
+	entries, err := s.Recent(0)
+	if err != nil || filter.IsZero() {
+		return entries, err
+	}
+	var matched []Entry
+	for _, entry := range entries {
+		if filter.Match(entry.Video) {
+			matched = append(matched, entry)
+		}
+	}
+	return matched, nil
+}
@@ -0,0 +1,59 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Banh-Canh/ytui/pkg/youtube"
+)
+
+func TestFilter_Match(t *testing.T) {
+	day := time.Date(2025, 3, 1, 20, 0, 0, 0, time.UTC)
+	watched := youtube.SearchResultItem{
+		VideoID:    "a",
+		Title:      "Building a Compiler",
+		Author:     "Tsoding",
+		AuthorID:   "UC1",
+		ViewedDate: day.Unix(),
+	}
+
+	tests := []struct {
+		name     string
+		filter   Filter
+		expected bool
+	}{
+		{"zero", Filter{}, true},
+		{"title", Filter{Query: "compiler"}, true},
+		{"channel name in query", Filter{Query: "tsod"}, true},
+		{"no match", Filter{Query: "cooking"}, false},
+		{"channel ID", Filter{Channel: "UC1"}, true},
+		{"channel name", Filter{Channel: "tsoding"}, true},
+		{"other channel", Filter{Channel: "UC10"}, false},
+		{"in range", Filter{From: day.Add(-time.Hour), To: day.Add(time.Hour)}, true},
+		{"to is exclusive", Filter{To: day}, false},
+		{"from is inclusive", Filter{From: day}, true},
+		{"before range", Filter{From: day.Add(time.Hour)}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.filter.Match(watched))
+		})
+	}
+}
+
+func TestStore_Find(t *testing.T) {
+	store := openTestStore(t)
+	day := time.Date(2025, 3, 1, 20, 0, 0, 0, time.UTC)
+
+	require.NoError(t, store.Add(video("a", "UC1"), day))
+	require.NoError(t, store.Add(video("b", "UC2"), day.Add(time.Hour)))
+	require.NoError(t, store.Add(video("c", "UC1"), day.Add(2*time.Hour)))
+
+	entries, err := store.Find(Filter{Channel: "UC1", From: day.Add(time.Minute)})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "c", entries[0].Video.VideoID)
+}
@@ -1,113 +1,136 @@
 package history
 
 import (
-	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
-	"path/filepath"
 	"time"
 
+	bolt "go.etcd.io/bbolt"
 	"go.uber.org/zap"
 
 	"github.com/Banh-Canh/ytui/internal/utils"
 	"github.com/Banh-Canh/ytui/pkg/youtube"
 )
 
-// Save saves the history to the specified file
-func Save(history []youtube.SearchResultItem, filename string) error {
-	dir := filepath.Dir(filename)
-	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
-		utils.Logger.Error("Failed to create directory for history file.", zap.String("directory", dir), zap.Error(err))
-		return err
-	}
+// LegacyFileName is the JSON history written before the database existed
+const LegacyFileName = "watched_history.json"
 
-	file, err := os.Create(filename)
-	if err != nil {
-		utils.Logger.Error("Failed to create history file.", zap.String("filename", filename), zap.Error(err))
-		return err
-	}
-	defer file.Close()
+// migratedSuffix is appended to the legacy file once imported, keeping it as a backup
+const migratedSuffix = ".migrated"
 
-	data, err := json.MarshalIndent(history, "", "  ")
-	if err != nil {
-		utils.Logger.Error("Failed to marshal history data.", zap.String("filename", filename), zap.Error(err))
-		return err
-	}
+// legacyMigratedKey in metaBucket records when the legacy JSON history was imported
+var legacyMigratedKey = []byte("legacy_json_migrated")
 
-	err = os.WriteFile(filename, data, 0o644)
+// MigrateJSON imports the legacy JSON history, an array with one entry per play, then
+// renames the file. The import is recorded in the same transaction, so a file that
+// could not be renamed is retired on the next start without being imported twice. A
+// damaged file is imported up to the damage and quarantined instead of failing every
+// start.
+func (s *Store) MigrateJSON(path string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
 	if err != nil {
-		utils.Logger.Error("Failed to write history data to file.", zap.String("filename", filename), zap.Error(err))
-		return err
+		return fmt.Errorf("failed to read legacy history: %w", err)
 	}
 
-	utils.Logger.Info("Successfully saved history data.", zap.String("filename", filename), zap.Int("item_count", len(history)))
-	return nil
-}
+	videos, parseErr := decodeLegacy(data)
 
-// Load loads the watch history from the specified file
-func Load(filename string) ([]youtube.SearchResultItem, error) {
-	var history []youtube.SearchResultItem
+	// Entries without a date predate ViewedDate; the file's age is the best guess
+	fallback := time.Now()
+	if info, err := os.Stat(path); err == nil {
+		fallback = info.ModTime()
+	}
 
-	data, err := os.ReadFile(filename)
-	if os.IsNotExist(err) {
-		// Create empty history file if it doesn't exist
-		emptyHistory := []youtube.SearchResultItem{}
-		emptyHistoryData, err := json.Marshal(emptyHistory)
-		if err != nil {
-			utils.Logger.Error("Failed to marshal empty history.", zap.Error(err))
-			return nil, err
+	alreadyMigrated := false
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		meta := tx.Bucket(metaBucket)
+		if meta.Get(legacyMigratedKey) != nil {
+			alreadyMigrated = true
+			return nil
 		}
-		
-		err = os.WriteFile(filename, emptyHistoryData, 0o644)
-		if err != nil {
-			utils.Logger.Error("Failed to write empty history file.", zap.Error(err))
-			return nil, err
+		for _, video := range videos {
+			if video.VideoID == "" {
+				continue
+			}
+			at := fallback
+			if video.ViewedDate > 0 {
+				at = time.Unix(video.ViewedDate, 0)
+			}
+			if err := addPlay(tx, video, at); err != nil {
+				return err
+			}
 		}
-		
-		utils.Logger.Info("Created new empty history file.", zap.String("filename", filename))
-		return emptyHistory, nil
-	}
-	
+		return meta.Put(legacyMigratedKey, timePrefix(time.Now()))
+	})
 	if err != nil {
-		utils.Logger.Error("Failed to read history file.", zap.Error(err))
-		return nil, err
+		return fmt.Errorf("failed to import legacy history: %w", err)
 	}
-
-	err = json.Unmarshal(data, &history)
-	if err != nil {
-		utils.Logger.Error("Failed to unmarshal history data.", zap.Error(err))
-		return nil, err
+	if alreadyMigrated {
+		utils.Logger.Warn("Legacy watch history was already imported, retiring it again.", zap.String("filename", path))
+		if err := os.Rename(path, path+migratedSuffix); err != nil {
+			return fmt.Errorf("failed to retire legacy history: %w", err)
+		}
+		return nil
 	}
 
-	// Reverse the history to show most recent at the top
-	for i, j := 0, len(history)-1; i < j; i, j = i+1, j-1 {
-		history[i], history[j] = history[j], history[i]
+	if parseErr != nil {
+		quarantined, err := quarantine(path)
+		if err != nil {
+			return fmt.Errorf("failed to quarantine damaged legacy history: %w", err)
+		}
+		utils.Logger.Warn("Legacy watch history is damaged, imported what could be read.",
+			zap.String("quarantined", quarantined), zap.Int("plays", len(videos)), zap.Error(parseErr))
+		return nil
 	}
-
-	utils.Logger.Info("Successfully retrieved and processed watched videos.", zap.Int("count", len(history)))
-	return history, nil
+	if err := os.Rename(path, path+migratedSuffix); err != nil {
+		return fmt.Errorf("failed to retire legacy history: %w", err)
+	}
+	utils.Logger.Info("Migrated JSON watch history to database.", zap.String("filename", path), zap.Int("plays", len(videos)))
+	return nil
 }
 
-// Add adds a video to the watch history
-func Add(video youtube.SearchResultItem, historyFilePath string) error {
-	currentTime := time.Now().Unix()
-	video.ViewedDate = currentTime
-	
-	history, err := Load(historyFilePath)
+// Videos returns the watched videos of a config directory, last watched first, with
+// ViewedDate set to the last play
+func Videos(configDir string) ([]youtube.SearchResultItem, error) {
+	entries, err := fromStore(configDir, func(store *Store) ([]Entry, error) {
+		entries, err := store.Recent(0)
+		if err != nil {
+			utils.Logger.Error("Failed to read history.", zap.Error(err))
+		}
+		return entries, err
+	})
 	if err != nil {
-		utils.Logger.Error("Failed to read history.", zap.Error(err))
-		return err
+		return nil, err
+	}
+	videos := make([]youtube.SearchResultItem, 0, len(entries))
+	for _, entry := range entries {
+		videos = append(videos, entry.Video)
 	}
-	
-	history = append(history, video)
-	return Save(history, historyFilePath)
+	utils.Logger.Info("Successfully retrieved watched videos.", zap.Int("count", len(videos)))
+	return videos, nil
 }
 
-// GetWatchedVideos is an alias for Load for backward compatibility
-func GetWatchedVideos(filename string) ([]youtube.SearchResultItem, error) {
-	return Load(filename)
+// Record adds a play of video now to the history of a config directory, then prunes
+// the history down to the retention
+func Record(video youtube.SearchResultItem, retention Retention, configDir string) error {
+	return withStore(configDir, func(store *Store) error {
+		now := time.Now()
+		if err := store.Add(video, now); err != nil {
+			utils.Logger.Error("Failed to record play.", zap.String("videoID", video.VideoID), zap.Error(err))
+			return err
+		}
+		pruned, err := store.Prune(retention, now)
+		if err != nil {
+			// The play is recorded, the next one prunes again
+			utils.Logger.Error("Failed to prune history.", zap.Error(err))
+			return nil
+		}
+		if len(pruned.Plays) > 0 {
+			utils.Logger.Info("Pruned history.", zap.Int("videos", pruned.Len()), zap.Int("plays", len(pruned.Plays)))
+		}
+		return nil
+	})
 }
-
-// FeedHistory is an alias for Add for backward compatibility  
-func FeedHistory(selectedVideo youtube.SearchResultItem, historyFilePath string) error {
-	return Add(selectedVideo, historyFilePath)
-}
\ No newline at end of file
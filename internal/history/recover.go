@@ -0,0 +1,58 @@
+package history
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	berrors "go.etcd.io/bbolt/errors"
+
+	"github.com/Banh-Canh/ytui/pkg/youtube"
+)
+
+// isCorrupted reports whether opening the database failed on its content rather than
+// on access or locking
+func isCorrupted(err error) bool {
+	return errors.Is(err, berrors.ErrInvalid) ||
+		errors.Is(err, berrors.ErrChecksum) ||
+		errors.Is(err, berrors.ErrVersionMismatch)
+}
+
+// quarantine moves a corrupted file aside, keeping it for inspection, and returns its new path
+func quarantine(path string) (string, error) {
+	quarantined := fmt.Sprintf("%s.corrupt-%s", path, time.Now().Format("20060102-150405"))
+	if err := os.Rename(path, quarantined); err != nil {
+		return "", err
+	}
+	return quarantined, nil
+}
+
+// decodeLegacy reads a JSON history. When the file is damaged, for instance truncated
+// by a crash while it was rewritten, the entries before the damage are returned along
+// with the parse error.
+func decodeLegacy(data []byte) ([]youtube.SearchResultItem, error) {
+	var videos []youtube.SearchResultItem
+	if len(data) == 0 {
+		return nil, nil
+	}
+	if err := json.Unmarshal(data, &videos); err == nil {
+		return videos, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+		return nil, fmt.Errorf("history is not a JSON list")
+	}
+	for decoder.More() {
+		var video youtube.SearchResultItem
+		if err := decoder.Decode(&video); err != nil {
+			return videos, err
+		}
+		videos = append(videos, video)
+	}
+	// The list is complete, the damage is after it
+	return videos, fmt.Errorf("unexpected data after the history list")
+}
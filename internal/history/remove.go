@@ -0,0 +1,155 @@
+package history
+
+import (
+	"bytes"
+	"encoding/json"
+
+	bolt "go.etcd.io/bbolt"
+	"go.uber.org/zap"
+
+	"github.com/Banh-Canh/ytui/internal/utils"
+)
+
+// Removed holds what Remove deleted, so that Restore can bring it back
+type Removed struct {
+	Entries []Entry
+	Plays   []Play
+}
+
+// Len returns the number of videos removed
+func (r Removed) Len() int {
+	return len(r.Entries)
+}
+
+// Remove deletes videos from the history along with their plays. Unknown IDs are ignored.
+func (s *Store) Remove(videoIDs ...string) (Removed, error) {
+	var removed Removed
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		var err error
+		removed, err = removeVideos(tx, videoIDs)
+		return err
+	})
+	if err != nil {
+		return Removed{}, err
+	}
+	return removed, nil
+}
+
+// removeVideos is Remove within a transaction
+func removeVideos(tx *bolt.Tx, videoIDs []string) (Removed, error) {
+	var removed Removed
+	videos := tx.Bucket(videosBucket)
+	wanted := make(map[string]bool, len(videoIDs))
+	for _, videoID := range videoIDs {
+		entry, found := getEntry(videos, videoID)
+		if !found {
+			// Drop a corrupted entry too, it can't be listed anyway
+			if err := videos.Delete([]byte(videoID)); err != nil {
+				return Removed{}, err
+			}
+			continue
+		}
+		if err := deleteEntry(tx, entry); err != nil {
+			return Removed{}, err
+		}
+		wanted[videoID] = true
+		removed.Entries = append(removed.Entries, entry)
+	}
+	if len(wanted) == 0 {
+		return removed, nil
+	}
+
+	// Collect the keys first, a cursor skips keys deleted under it
+	var keys [][]byte
+	cursor := tx.Bucket(playsBucket).Cursor()
+	for key, videoID := cursor.First(); key != nil; key, videoID = cursor.Next() {
+		if wanted[string(videoID)] {
+			keys = append(keys, bytes.Clone(key))
+			removed.Plays = append(removed.Plays, Play{VideoID: string(videoID), At: keyTime(key)})
+		}
+	}
+	for _, key := range keys {
+		if err := tx.Bucket(playsBucket).Delete(key); err != nil {
+			return Removed{}, err
+		}
+	}
+	return removed, nil
+}
+
+// Restore puts back what Remove deleted
+func (s *Store) Restore(removed Removed) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		for _, entry := range removed.Entries {
+			data, err := json.Marshal(entry)
+			if err != nil {
+				return err
+			}
+			if err := tx.Bucket(videosBucket).Put([]byte(entry.Video.VideoID), data); err != nil {
+				return err
+			}
+			if err := tx.Bucket(dateIndex).Put(timeKey(entry.LastWatched, entry.Video.VideoID), []byte(entry.Video.VideoID)); err != nil {
+				return err
+			}
+			if err := tx.Bucket(channelIndex).Put(channelKey(entry.Video.AuthorID, entry.Video.VideoID), nil); err != nil {
+				return err
+			}
+		}
+		for _, play := range removed.Plays {
+			if err := tx.Bucket(playsBucket).Put(timeKey(play.At, play.VideoID), []byte(play.VideoID)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Clear deletes the whole history
+func (s *Store) Clear() error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		for _, name := range allBuckets {
+			if err := tx.DeleteBucket(name); err != nil {
+				return err
+			}
+			if _, err := tx.CreateBucket(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// deleteEntry deletes the entry of a video and its index keys
+func deleteEntry(tx *bolt.Tx, entry Entry) error {
+	videoID := entry.Video.VideoID
+	if err := tx.Bucket(videosBucket).Delete([]byte(videoID)); err != nil {
+		return err
+	}
+	if err := tx.Bucket(dateIndex).Delete(timeKey(entry.LastWatched, videoID)); err != nil {
+		return err
+	}
+	return tx.Bucket(channelIndex).Delete(channelKey(entry.Video.AuthorID, videoID))
+}
+
+// Remove deletes videos from the history of a config directory
+func Remove(videoIDs []string, configDir string) (Removed, error) {
+	return fromStore(configDir, func(store *Store) (Removed, error) {
+		removed, err := store.Remove(videoIDs...)
+		if err != nil {
+			utils.Logger.Error("Failed to remove history entries.", zap.Strings("videoIDs", videoIDs), zap.Error(err))
+			return Removed{}, err
+		}
+		utils.Logger.Info("Removed history entries.", zap.Int("videos", removed.Len()), zap.Int("plays", len(removed.Plays)))
+		return removed, nil
+	})
+}
+
+// Restore puts back videos removed from the history of a config directory
+func Restore(removed Removed, configDir string) error {
+	return withStore(configDir, func(store *Store) error {
+		if err := store.Restore(removed); err != nil {
+			utils.Logger.Error("Failed to restore history entries.", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+}
@@ -0,0 +1,57 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_RemoveAndRestore(t *testing.T) {
+	store := openTestStore(t)
+	day := time.Date(2025, 3, 1, 20, 0, 0, 0, time.UTC)
+
+	require.NoError(t, store.Add(video("a", "UC1"), day))
+	require.NoError(t, store.Add(video("b", "UC1"), day.Add(time.Hour)))
+	require.NoError(t, store.Add(video("a", "UC1"), day.Add(2*time.Hour)))
+
+	removed, err := store.Remove("a", "unknown")
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed.Len())
+	assert.Len(t, removed.Plays, 2)
+
+	entries, err := store.Recent(0)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "b", entries[0].Video.VideoID)
+	byChannel, err := store.ByChannel("UC1")
+	require.NoError(t, err)
+	assert.Len(t, byChannel, 1)
+	plays, err := store.Plays(time.Time{}, time.Time{})
+	require.NoError(t, err)
+	assert.Len(t, plays, 1)
+
+	require.NoError(t, store.Restore(removed))
+	entries, err = store.Recent(0)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "a", entries[0].Video.VideoID)
+	assert.Equal(t, 2, entries[0].WatchCount)
+	plays, err = store.Plays(time.Time{}, time.Time{})
+	require.NoError(t, err)
+	assert.Len(t, plays, 3)
+}
+
+func TestStore_Clear(t *testing.T) {
+	store := openTestStore(t)
+	require.NoError(t, store.Add(video("a", "UC1"), time.Now()))
+
+	require.NoError(t, store.Clear())
+	entries, err := store.Recent(0)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	// The store stays usable
+	require.NoError(t, store.Add(video("b", "UC1"), time.Now()))
+}
@@ -0,0 +1,83 @@
+package history
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Banh-Canh/ytui/internal/utils"
+)
+
+const (
+	// minResumePosition is how far a video must have been played to be resumed
+	minResumePosition = 30 * time.Second
+	// finishedMargin before the end counts as watched to the end, credits and end screens included
+	finishedMargin = 30 * time.Second
+	// resumeRewind replays a few seconds before where the playback stopped
+	resumeRewind = 5 * time.Second
+)
+
+// length returns the length of the video, from the player or from its metadata
+func (e Entry) length() time.Duration {
+	if e.Length > 0 {
+		return e.Length
+	}
+	return e.Video.Length()
+}
+
+// Fraction returns how much of the video was played, between 0 and 1
+func (e Entry) Fraction() float64 {
+	length := e.length()
+	if length <= 0 || e.Position <= 0 {
+		return 0
+	}
+	if e.Position >= length {
+		return 1
+	}
+	return float64(e.Position) / float64(length)
+}
+
+// PlayedToEnd reports whether the playback reached the end of the video, credits aside
+func (e Entry) PlayedToEnd() bool {
+	length := e.length()
+	return length > 0 && e.Position >= length-finishedMargin
+}
+
+// ResumePosition returns where to resume a partially watched video, false when it was
+// barely started or watched to the end
+func (e Entry) ResumePosition() (time.Duration, bool) {
+	length := e.length()
+	if e.Position < minResumePosition || length <= 0 || e.Position >= length-finishedMargin {
+		return 0, false
+	}
+	return e.Position - resumeRewind, true
+}
+
+// Resume returns where to resume a video of the history of a config directory
+func Resume(videoID, configDir string) (time.Duration, bool) {
+	entry, err := fromStore(configDir, func(store *Store) (Entry, error) {
+		entry, _, err := store.Get(videoID)
+		return entry, err
+	})
+	if err != nil {
+		return 0, false
+	}
+	return entry.ResumePosition()
+}
+
+// SavePosition records where the playback of a video stopped in the history of a config directory
+func SavePosition(videoID string, position, length time.Duration, configDir string) error {
+	return withStore(configDir, func(store *Store) error {
+		if err := store.SetPosition(videoID, position, length); err != nil {
+			utils.Logger.Error("Failed to save playback position.", zap.String("videoID", videoID), zap.Error(err))
+			return err
+		}
+		return nil
+	})
+}
+
+// ContinueWatching returns the partially watched videos of a config directory, last
+// watched first
+func ContinueWatching(configDir string) ([]Entry, error) {
+	return fromStore(configDir, (*Store).InProgress)
+}
@@ -0,0 +1,82 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntry_ResumePosition(t *testing.T) {
+	tests := []struct {
+		name     string
+		entry    Entry
+		expected time.Duration
+		ok       bool
+	}{
+		{"never played", Entry{Length: 10 * time.Minute}, 0, false},
+		{"barely started", Entry{Position: 20 * time.Second, Length: 10 * time.Minute}, 0, false},
+		{"in the middle", Entry{Position: 4 * time.Minute, Length: 10 * time.Minute}, 4*time.Minute - resumeRewind, true},
+		{"in the credits", Entry{Position: 9*time.Minute + 45*time.Second, Length: 10 * time.Minute}, 0, false},
+		{"unknown length", Entry{Position: 4 * time.Minute}, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			position, ok := tt.entry.ResumePosition()
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, position)
+		})
+	}
+}
+
+func TestEntry_Fraction(t *testing.T) {
+	assert.Equal(t, 0.25, Entry{Position: 5 * time.Minute, Length: 20 * time.Minute}.Fraction())
+	assert.Equal(t, 1.0, Entry{Position: 25 * time.Minute, Length: 20 * time.Minute}.Fraction())
+	assert.Zero(t, Entry{Position: 5 * time.Minute}.Fraction())
+}
+
+func TestEntry_PlayedToEnd(t *testing.T) {
+	assert.True(t, Entry{Position: 19*time.Minute + 45*time.Second, Length: 20 * time.Minute}.PlayedToEnd(), "in the credits")
+	assert.False(t, Entry{Position: 10 * time.Minute, Length: 20 * time.Minute}.PlayedToEnd())
+	assert.False(t, Entry{Position: 10 * time.Minute}.PlayedToEnd(), "unknown length")
+}
+
+func TestStore_InProgress(t *testing.T) {
+	store := openTestStore(t)
+	day := time.Date(2025, 3, 1, 20, 0, 0, 0, time.UTC)
+
+	require.NoError(t, store.Add(video("a", "UC1"), day))
+	require.NoError(t, store.Add(video("b", "UC1"), day.Add(time.Hour)))
+	require.NoError(t, store.Add(video("c", "UC1"), day.Add(2*time.Hour)))
+	require.NoError(t, store.SetPosition("a", 3*time.Minute, 10*time.Minute))
+	require.NoError(t, store.SetPosition("b", 10*time.Minute, 10*time.Minute))
+	require.NoError(t, store.SetPosition("unknown", 3*time.Minute, 10*time.Minute), "videos never played are ignored")
+
+	entries, err := store.InProgress()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "a", entries[0].Video.VideoID)
+	assert.Equal(t, 3*time.Minute, entries[0].Position)
+
+	// Playing it again keeps the position until the player reports a new one
+	require.NoError(t, store.Add(video("a", "UC1"), day.Add(3*time.Hour)))
+	entry, found, err := store.Get("a")
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, 3*time.Minute, entry.Position)
+}
+
+func TestSavePosition_Resume(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, Record(video("a", "UC1"), Retention{}, dir))
+	require.NoError(t, SavePosition("a", 2*time.Minute, 8*time.Minute, dir))
+
+	position, ok := Resume("a", dir)
+	require.True(t, ok)
+	assert.Equal(t, 2*time.Minute-resumeRewind, position)
+
+	entries, err := ContinueWatching(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
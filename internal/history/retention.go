@@ -0,0 +1,135 @@
+package history
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// Retention bounds how much history is kept. Its zero value keeps everything.
+type Retention struct {
+	// Days drops the plays older than this many days, and the videos not watched since
+	Days int
+	// MaxEntries keeps only this many videos, the last watched ones
+	MaxEntries int
+}
+
+// IsZero reports whether the retention keeps everything
+func (r Retention) IsZero() bool {
+	return r.Days <= 0 && r.MaxEntries <= 0
+}
+
+// Prune applies the retention, returning what it deleted. Videos that keep their entry
+// but lose older plays get their watch count and first play updated.
+func (s *Store) Prune(retention Retention, now time.Time) (Removed, error) {
+	if retention.IsZero() {
+		return Removed{}, nil
+	}
+	var cutoff time.Time
+	if retention.Days > 0 {
+		cutoff = now.AddDate(0, 0, -retention.Days)
+	}
+
+	var removed Removed
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		var err error
+		removed, err = removeVideos(tx, expiredVideos(tx, retention.MaxEntries, cutoff))
+		if err != nil || cutoff.IsZero() {
+			return err
+		}
+
+		// Videos still watched recently keep their entry but lose their older plays
+		plays := tx.Bucket(playsBucket)
+		var (
+			keys    [][]byte
+			dropped []Play
+		)
+		cursor := plays.Cursor()
+		for key, videoID := cursor.First(); key != nil && keyTime(key).Before(cutoff); key, videoID = cursor.Next() {
+			keys = append(keys, bytes.Clone(key))
+			dropped = append(dropped, Play{VideoID: string(videoID), At: keyTime(key)})
+		}
+		for _, key := range keys {
+			if err := plays.Delete(key); err != nil {
+				return err
+			}
+		}
+		removed.Plays = append(removed.Plays, dropped...)
+		return recountPlays(tx, dropped)
+	})
+	if err != nil {
+		return Removed{}, err
+	}
+	return removed, nil
+}
+
+// expiredVideos returns the videos beyond the maxEntries last watched ones and the ones not
+// watched since cutoff. It walks the date index from both ends without reading the entries.
+func expiredVideos(tx *bolt.Tx, maxEntries int, cutoff time.Time) []string {
+	var expired []string
+	cursor := tx.Bucket(dateIndex).Cursor()
+	tooOld := func(key []byte) bool {
+		return !cutoff.IsZero() && keyTime(key).Before(cutoff)
+	}
+	for key, videoID := cursor.First(); key != nil && tooOld(key); key, videoID = cursor.Next() {
+		expired = append(expired, string(videoID))
+	}
+	if maxEntries <= 0 {
+		return expired
+	}
+	kept := 0
+	// The videos too old are already listed, from the oldest one on
+	for key, videoID := cursor.Last(); key != nil && !tooOld(key); key, videoID = cursor.Prev() {
+		if kept < maxEntries {
+			kept++
+			continue
+		}
+		expired = append(expired, string(videoID))
+	}
+	return expired
+}
+
+// recountPlays updates the entries left of the videos whose plays were dropped: their watch
+// count, and their first play, the oldest one left or else the last one
+func recountPlays(tx *bolt.Tx, dropped []Play) error {
+	videos := tx.Bucket(videosBucket)
+	entries := make(map[string]Entry)
+	for _, play := range dropped {
+		entry, found := entries[play.VideoID]
+		if !found {
+			if entry, found = getEntry(videos, play.VideoID); !found {
+				// Removed along with its plays
+				continue
+			}
+		}
+		entry.WatchCount = max(entry.WatchCount-1, 0)
+		entry.FirstWatched = entry.LastWatched
+		entries[play.VideoID] = entry
+	}
+
+	// The plays left are in time order, the first one of a video is its first play
+	firstFound := make(map[string]bool, len(entries))
+	cursor := tx.Bucket(playsBucket).Cursor()
+	for key, videoID := cursor.First(); key != nil && len(firstFound) < len(entries); key, videoID = cursor.Next() {
+		entry, ok := entries[string(videoID)]
+		if !ok || firstFound[string(videoID)] {
+			continue
+		}
+		firstFound[string(videoID)] = true
+		entry.FirstWatched = keyTime(key)
+		entries[string(videoID)] = entry
+	}
+
+	for videoID, entry := range entries {
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		if err := videos.Put([]byte(videoID), data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_PruneDays(t *testing.T) {
+	store := openTestStore(t)
+	now := time.Date(2025, 3, 31, 20, 0, 0, 0, time.UTC)
+
+	require.NoError(t, store.Add(video("old", "UC1"), now.AddDate(0, 0, -40)))
+	require.NoError(t, store.Add(video("a", "UC1"), now.AddDate(0, 0, -45)))
+	require.NoError(t, store.Add(video("a", "UC1"), now.AddDate(0, 0, -1)))
+
+	removed, err := store.Prune(Retention{Days: 30}, now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed.Len())
+	assert.Len(t, removed.Plays, 2)
+
+	entries, err := store.Recent(0)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "a", entries[0].Video.VideoID)
+	plays, err := store.Plays(time.Time{}, time.Time{})
+	require.NoError(t, err)
+	assert.Len(t, plays, 1, "older plays of kept videos are dropped")
+	assert.Equal(t, 1, entries[0].WatchCount, "the dropped plays are not counted")
+	assert.True(t, now.AddDate(0, 0, -1).Equal(entries[0].FirstWatched), "first watched at the oldest play left")
+}
+
+func TestStore_PruneDaysAndMaxEntries(t *testing.T) {
+	store := openTestStore(t)
+	now := time.Date(2025, 3, 31, 20, 0, 0, 0, time.UTC)
+	require.NoError(t, store.Add(video("old", "UC1"), now.AddDate(0, 0, -40)))
+	require.NoError(t, store.Add(video("c", "UC1"), now.AddDate(0, 0, -35)))
+	for i, id := range []string{"a", "b", "c"} {
+		require.NoError(t, store.Add(video(id, "UC1"), now.Add(time.Duration(i-3)*time.Hour)))
+	}
+
+	removed, err := store.Prune(Retention{Days: 30, MaxEntries: 2}, now)
+	require.NoError(t, err)
+	var removedIDs []string
+	for _, entry := range removed.Entries {
+		removedIDs = append(removedIDs, entry.Video.VideoID)
+	}
+	assert.ElementsMatch(t, []string{"old", "a"}, removedIDs)
+
+	entries, err := store.Recent(0)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	c := entries[0]
+	assert.Equal(t, "c", c.Video.VideoID)
+	assert.Equal(t, 1, c.WatchCount)
+	assert.True(t, now.Add(-time.Hour).Equal(c.FirstWatched))
+}
+
+func TestStore_PruneMaxEntries(t *testing.T) {
+	store := openTestStore(t)
+	now := time.Date(2025, 3, 31, 20, 0, 0, 0, time.UTC)
+	for i, id := range []string{"a", "b", "c"} {
+		require.NoError(t, store.Add(video(id, "UC1"), now.Add(time.Duration(i)*time.Hour)))
+	}
+
+	removed, err := store.Prune(Retention{MaxEntries: 2}, now)
+	require.NoError(t, err)
+	require.Equal(t, 1, removed.Len())
+	assert.Equal(t, "a", removed.Entries[0].Video.VideoID)
+
+	removed, err = store.Prune(Retention{}, now)
+	require.NoError(t, err)
+	assert.Zero(t, removed.Len(), "the zero retention keeps everything")
+}
+
+func TestRecord_Prunes(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, Record(video("a", "UC1"), Retention{MaxEntries: 1}, dir))
+	require.NoError(t, Record(video("b", "UC1"), Retention{MaxEntries: 1}, dir))
+
+	videos, err := Videos(dir)
+	require.NoError(t, err)
+	require.Len(t, videos, 1)
+	assert.Equal(t, "b", videos[0].VideoID)
+}
@@ -0,0 +1,218 @@
+package history
+
+import (
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Banh-Canh/ytui/internal/utils"
+)
+
+// topChannels is how many channels Stats ranks
+const topChannels = 10
+
+// Stats aggregates the plays of a period. Watch times are estimates: a play counts as
+// the whole video, except the last play of a video stopped midway, which counts up to
+// where it stopped.
+type Stats struct {
+	From          time.Time `json:"from"`
+	To            time.Time `json:"to"`
+	Plays         int       `json:"plays"`
+	Videos        int       `json:"videos"`
+	WatchSeconds  int64     `json:"watchSeconds"`
+	AverageLength int64     `json:"averageLengthSeconds"` // Over the videos of known length
+	// Days and Weeks cover the period, empty ones included. Weeks start on Monday.
+	Days        []Period       `json:"days"`
+	Weeks       []Period       `json:"weeks"`
+	TopChannels []ChannelStats `json:"topChannels"`
+	// Streaks count consecutive days with a play over the whole history. The current
+	// streak holds until a day ends without a play.
+	CurrentStreak int `json:"currentStreak"`
+	LongestStreak int `json:"longestStreak"`
+}
+
+// Period is a day or a week of Stats
+type Period struct {
+	Start        time.Time `json:"start"`
+	Plays        int       `json:"plays"`
+	WatchSeconds int64     `json:"watchSeconds"`
+}
+
+// ChannelStats is a channel of Stats
+type ChannelStats struct {
+	ChannelID    string `json:"channelId"`
+	Name         string `json:"name"`
+	Plays        int    `json:"plays"`
+	WatchSeconds int64  `json:"watchSeconds"`
+}
+
+// Stats aggregates the plays from the start of from's day until now
+func (s *Store) Stats(from, now time.Time) (Stats, error) {
+	plays, err := s.Plays(time.Time{}, time.Time{})
+	if err != nil {
+		return Stats{}, err
+	}
+	recent, err := s.Recent(0)
+	if err != nil {
+		return Stats{}, err
+	}
+	entries := make(map[string]Entry, len(recent))
+	for _, entry := range recent {
+		entries[entry.Video.VideoID] = entry
+	}
+	return summarize(plays, entries, from, now), nil
+}
+
+// StatsSince aggregates the history of a config directory from the start of from's day until now
+func StatsSince(from time.Time, configDir string) (Stats, error) {
+	return fromStore(configDir, func(store *Store) (Stats, error) {
+		stats, err := store.Stats(from, time.Now())
+		if err != nil {
+			utils.Logger.Error("Failed to compute history stats.", zap.Error(err))
+			return Stats{}, err
+		}
+		return stats, nil
+	})
+}
+
+// summarize computes Stats from the plays, oldest first, and the entries by video ID.
+// Days follow the time zone of now.
+func summarize(plays []Play, entries map[string]Entry, from, now time.Time) Stats {
+	from = startOfDay(from.In(now.Location()))
+	stats := Stats{From: from, To: now}
+
+	dayIndex := make(map[time.Time]int)
+	for day := from; !day.After(now); day = day.AddDate(0, 0, 1) {
+		dayIndex[day] = len(stats.Days)
+		stats.Days = append(stats.Days, Period{Start: day})
+	}
+	weekIndex := make(map[time.Time]int)
+	for week := startOfWeek(from); !week.After(now); week = week.AddDate(0, 0, 7) {
+		weekIndex[week] = len(stats.Weeks)
+		stats.Weeks = append(stats.Weeks, Period{Start: week})
+	}
+
+	var (
+		channels   = make(map[string]*ChannelStats)
+		videos     = make(map[string]bool)
+		playedDays = make(map[time.Time]bool)
+	)
+	for _, play := range plays {
+		at := play.At.In(now.Location())
+		playedDays[startOfDay(at)] = true
+		if at.Before(from) || at.After(now) {
+			continue
+		}
+		entry := entries[play.VideoID]
+		watched := int64(playedLength(entry, play).Seconds())
+
+		stats.Plays++
+		stats.WatchSeconds += watched
+		videos[play.VideoID] = true
+		if i, ok := dayIndex[startOfDay(at)]; ok {
+			stats.Days[i].Plays++
+			stats.Days[i].WatchSeconds += watched
+		}
+		if i, ok := weekIndex[startOfWeek(at)]; ok {
+			stats.Weeks[i].Plays++
+			stats.Weeks[i].WatchSeconds += watched
+		}
+
+		channelID := entry.Video.AuthorID
+		if channelID == "" {
+			channelID = entry.Video.Author
+		}
+		channel, ok := channels[channelID]
+		if !ok {
+			channel = &ChannelStats{ChannelID: entry.Video.AuthorID, Name: entry.Video.Author}
+			channels[channelID] = channel
+		}
+		channel.Plays++
+		channel.WatchSeconds += watched
+	}
+
+	stats.Videos = len(videos)
+	var total, known int64
+	for videoID := range videos {
+		if length := entries[videoID].length(); length > 0 {
+			total += int64(length.Seconds())
+			known++
+		}
+	}
+	if known > 0 {
+		stats.AverageLength = total / known
+	}
+
+	stats.TopChannels = make([]ChannelStats, 0, len(channels))
+	for _, channel := range channels {
+		stats.TopChannels = append(stats.TopChannels, *channel)
+	}
+	sort.Slice(stats.TopChannels, func(i, j int) bool {
+		a, b := stats.TopChannels[i], stats.TopChannels[j]
+		if a.Plays != b.Plays {
+			return a.Plays > b.Plays
+		}
+		if a.WatchSeconds != b.WatchSeconds {
+			return a.WatchSeconds > b.WatchSeconds
+		}
+		return a.Name < b.Name
+	})
+	if len(stats.TopChannels) > topChannels {
+		stats.TopChannels = stats.TopChannels[:topChannels]
+	}
+
+	stats.CurrentStreak, stats.LongestStreak = streaks(playedDays, startOfDay(now))
+	return stats
+}
+
+// playedLength estimates how much of the video a play watched
+func playedLength(entry Entry, play Play) time.Duration {
+	length := entry.length()
+	// Plays are bumped by a few nanoseconds when recorded at the same instant
+	isLast := !play.At.Before(entry.LastWatched) && play.At.Sub(entry.LastWatched) < time.Millisecond
+	if isLast && entry.Position > 0 && (length <= 0 || entry.Position < length) {
+		return entry.Position
+	}
+	return length
+}
+
+// streaks returns the current and the longest runs of consecutive played days. The
+// current run may end yesterday, today can still extend it.
+func streaks(playedDays map[time.Time]bool, today time.Time) (current, longest int) {
+	days := make([]time.Time, 0, len(playedDays))
+	for day := range playedDays {
+		days = append(days, day)
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+
+	run := 0
+	for i, day := range days {
+		if i > 0 && days[i-1].AddDate(0, 0, 1).Equal(day) {
+			run++
+		} else {
+			run = 1
+		}
+		longest = max(longest, run)
+	}
+
+	start := today
+	if !playedDays[start] {
+		start = start.AddDate(0, 0, -1)
+	}
+	for day := start; playedDays[day]; day = day.AddDate(0, 0, -1) {
+		current++
+	}
+	return current, longest
+}
+
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+func startOfWeek(t time.Time) time.Time {
+	day := startOfDay(t)
+	// Monday is the first day of the week
+	return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+}
@@ -0,0 +1,90 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Banh-Canh/ytui/pkg/youtube"
+)
+
+func TestSummarize(t *testing.T) {
+	// Wednesday
+	now := time.Date(2025, 3, 5, 21, 0, 0, 0, time.UTC)
+	at := func(daysAgo int) time.Time { return now.AddDate(0, 0, -daysAgo).Add(-time.Hour) }
+
+	entries := map[string]Entry{
+		"a": {Video: youtube.SearchResultItem{VideoID: "a", Author: "Gopher", AuthorID: "UC1", LengthSeconds: 600}, LastWatched: at(0)},
+		// Stopped after 2 minutes the last time
+		"b": {Video: youtube.SearchResultItem{VideoID: "b", Author: "Gopher", AuthorID: "UC1", LengthSeconds: 1200}, LastWatched: at(1), Position: 2 * time.Minute},
+		"c": {Video: youtube.SearchResultItem{VideoID: "c", Author: "Crab", AuthorID: "UC2"}, LastWatched: at(3)},
+	}
+	plays := []Play{
+		{VideoID: "a", At: at(20)}, // Before the period, still part of the streaks
+		{VideoID: "c", At: at(3)},
+		{VideoID: "b", At: at(2)},
+		{VideoID: "b", At: at(1)},
+		{VideoID: "a", At: at(0)},
+	}
+
+	stats := summarize(plays, entries, now.AddDate(0, 0, -6), now)
+
+	assert.Equal(t, 4, stats.Plays)
+	assert.Equal(t, 3, stats.Videos)
+	assert.Equal(t, int64(1200+120+600), stats.WatchSeconds, "c has no known length")
+	assert.Equal(t, int64(900), stats.AverageLength)
+
+	require.Len(t, stats.Days, 7)
+	assert.Equal(t, time.Date(2025, 2, 27, 0, 0, 0, 0, time.UTC), stats.Days[0].Start)
+	assert.Equal(t, Period{Start: time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC), Plays: 1, WatchSeconds: 120}, stats.Days[5])
+
+	require.Len(t, stats.Weeks, 2)
+	assert.Equal(t, time.Date(2025, 2, 24, 0, 0, 0, 0, time.UTC), stats.Weeks[0].Start, "weeks start on Monday")
+	assert.Equal(t, 1, stats.Weeks[0].Plays)
+	assert.Equal(t, 3, stats.Weeks[1].Plays)
+
+	require.Len(t, stats.TopChannels, 2)
+	assert.Equal(t, ChannelStats{ChannelID: "UC1", Name: "Gopher", Plays: 3, WatchSeconds: 1920}, stats.TopChannels[0])
+
+	assert.Equal(t, 4, stats.CurrentStreak)
+	assert.Equal(t, 4, stats.LongestStreak)
+}
+
+func TestStreaks(t *testing.T) {
+	today := time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC)
+	days := func(offsets ...int) map[time.Time]bool {
+		played := make(map[time.Time]bool)
+		for _, offset := range offsets {
+			played[today.AddDate(0, 0, -offset)] = true
+		}
+		return played
+	}
+
+	current, longest := streaks(days(1, 2, 5, 6, 7), today)
+	assert.Equal(t, 2, current, "today can still extend yesterday's streak")
+	assert.Equal(t, 3, longest)
+
+	current, longest = streaks(days(2, 3), today)
+	assert.Zero(t, current)
+	assert.Equal(t, 2, longest)
+
+	current, longest = streaks(nil, today)
+	assert.Zero(t, current)
+	assert.Zero(t, longest)
+}
+
+func TestStore_Stats(t *testing.T) {
+	store := openTestStore(t)
+	now := time.Now()
+	watched := video("a", "UC1")
+	watched.LengthSeconds = 300
+	require.NoError(t, store.Add(watched, now.Add(-time.Minute)))
+
+	stats, err := store.Stats(now.AddDate(0, 0, -7), now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Plays)
+	assert.Equal(t, int64(300), stats.WatchSeconds)
+	assert.Equal(t, 1, stats.CurrentStreak)
+}
@@ -0,0 +1,357 @@
+package history
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+	berrors "go.etcd.io/bbolt/errors"
+	"go.uber.org/zap"
+
+	"github.com/Banh-Canh/ytui/internal/utils"
+	"github.com/Banh-Canh/ytui/pkg/youtube"
+)
+
+// DBFileName is the history database in the profile's config directory
+const DBFileName = "history.db"
+
+// lockTimeout bounds the wait for another ytui instance holding the database
+const lockTimeout = 2 * time.Second
+
+var (
+	// videosBucket maps a video ID to its Entry
+	videosBucket = []byte("videos")
+	// playsBucket logs every play, keyed by play time then video ID
+	playsBucket = []byte("plays")
+	// dateIndex orders videos by last play, keyed by last play time then video ID
+	dateIndex = []byte("by_date")
+	// channelIndex groups videos by channel, keyed by channel ID, a zero byte and video ID
+	channelIndex = []byte("by_channel")
+
+	allBuckets = [][]byte{videosBucket, playsBucket, dateIndex, channelIndex}
+
+	// feedBucket maps a channel ID to the last visit of its videos in the subscriptions
+	// feed. It is not watch history and survives Clear.
+	feedBucket = []byte("feed_seen")
+	// metaBucket holds the state of the database itself, such as the migrations done
+	metaBucket = []byte("meta")
+	// watchLaterBucket holds the Watch Later queue, as encoded by package watchlater. It
+	// is not watch history and survives Clear.
+	watchLaterBucket = []byte("watch_later")
+
+	// keptBuckets are created along allBuckets but survive Clear
+	keptBuckets = [][]byte{feedBucket, metaBucket, watchLaterBucket}
+)
+
+// Entry is one watched video with its play statistics
+type Entry struct {
+	Video        youtube.SearchResultItem `json:"video"`
+	WatchCount   int                      `json:"watchCount"`
+	FirstWatched time.Time                `json:"firstWatched"`
+	LastWatched  time.Time                `json:"lastWatched"`
+	// Position is where the last playback stopped, zero when unknown
+	Position time.Duration `json:"position,omitempty"`
+	// Length is the video length reported by the player
+	Length time.Duration `json:"length,omitempty"`
+	// Unwatched is set when the video was marked unwatched, until it is played again
+	Unwatched bool `json:"unwatched,omitempty"`
+}
+
+// Play is one entry of the play log
+type Play struct {
+	VideoID string
+	At      time.Time
+}
+
+// Store is the watch history database. It keeps one entry per video, a log of every
+// play and indexes by date and channel.
+type Store struct {
+	db *bolt.DB
+}
+
+// Open opens the database at path, creating it if needed. Other ytui instances wait
+// for each other through the database's file lock. A corrupted database is
+// quarantined and replaced by an empty one rather than failing every start.
+func Open(path string) (*Store, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: lockTimeout})
+	if isCorrupted(err) {
+		quarantined, qerr := quarantine(path)
+		if qerr != nil {
+			return nil, fmt.Errorf("history database is corrupted and could not be moved aside: %w", qerr)
+		}
+		utils.Logger.Warn("History database is corrupted, starting a new one.", zap.String("quarantined", quarantined), zap.Error(err))
+		db, err = bolt.Open(path, 0o600, &bolt.Options{Timeout: lockTimeout})
+	}
+	if err != nil {
+		if errors.Is(err, berrors.ErrTimeout) {
+			return nil, fmt.Errorf("history database is in use by another ytui instance: %w", err)
+		}
+		return nil, fmt.Errorf("failed to open history database: %w", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range append(append([][]byte{}, allBuckets...), keptBuckets...) {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize history database: %w", err)
+	}
+	return &Store{db: db}, nil
+}
+
+// OpenInDir opens the database of a config directory, importing the legacy JSON history
+// and Watch Later queue the first time
+func OpenInDir(configDir string) (*Store, error) {
+	store, err := Open(filepath.Join(configDir, DBFileName))
+	if err != nil {
+		return nil, err
+	}
+	if err := store.MigrateJSON(filepath.Join(configDir, LegacyFileName)); err != nil {
+		store.Close()
+		return nil, err
+	}
+	if err := store.MigrateWatchLater(filepath.Join(configDir, LegacyWatchLaterFileName)); err != nil {
+		store.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+// Close releases the database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// withStore runs fn on the database of a config directory, opened for the time of fn
+func withStore(configDir string, fn func(*Store) error) error {
+	_, err := fromStore(configDir, func(store *Store) (struct{}, error) {
+		return struct{}{}, fn(store)
+	})
+	return err
+}
+
+// fromStore returns what fn reads from the database of a config directory, opened for
+// the time of fn
+func fromStore[T any](configDir string, fn func(*Store) (T, error)) (T, error) {
+	store, err := OpenInDir(configDir)
+	if err != nil {
+		utils.Logger.Error("Failed to open history.", zap.Error(err))
+		var zero T
+		return zero, err
+	}
+	defer store.Close()
+	return fn(store)
+}
+
+// Add records a play of video at the given time
+func (s *Store) Add(video youtube.SearchResultItem, at time.Time) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return addPlay(tx, video, at)
+	})
+}
+
+// addPlay updates the entry of the video, its index keys and the play log
+func addPlay(tx *bolt.Tx, video youtube.SearchResultItem, at time.Time) error {
+	videos := tx.Bucket(videosBucket)
+	byDate := tx.Bucket(dateIndex)
+
+	entry, found := getEntry(videos, video.VideoID)
+	if found {
+		if err := byDate.Delete(timeKey(entry.LastWatched, entry.Video.VideoID)); err != nil {
+			return err
+		}
+		if err := tx.Bucket(channelIndex).Delete(channelKey(entry.Video.AuthorID, entry.Video.VideoID)); err != nil {
+			return err
+		}
+	} else {
+		entry.FirstWatched = at
+	}
+
+	entry.WatchCount++
+	entry.Unwatched = false
+	if at.Before(entry.FirstWatched) {
+		entry.FirstWatched = at
+	}
+	// An older play, such as an imported one, keeps the newer metadata
+	if !at.Before(entry.LastWatched) {
+		entry.LastWatched = at
+		entry.Video = video
+	}
+	entry.Video.ViewedDate = entry.LastWatched.Unix()
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := videos.Put([]byte(video.VideoID), data); err != nil {
+		return err
+	}
+	if err := byDate.Put(timeKey(entry.LastWatched, video.VideoID), []byte(video.VideoID)); err != nil {
+		return err
+	}
+	if err := tx.Bucket(channelIndex).Put(channelKey(entry.Video.AuthorID, video.VideoID), nil); err != nil {
+		return err
+	}
+	// Plays of the same video at the same instant, from concurrent instances, stay apart
+	plays := tx.Bucket(playsBucket)
+	for plays.Get(timeKey(at, video.VideoID)) != nil {
+		at = at.Add(time.Nanosecond)
+	}
+	return plays.Put(timeKey(at, video.VideoID), []byte(video.VideoID))
+}
+
+// SetPosition records where the playback of a watched video stopped
+func (s *Store) SetPosition(videoID string, position, length time.Duration) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		videos := tx.Bucket(videosBucket)
+		entry, found := getEntry(videos, videoID)
+		if !found {
+			return nil
+		}
+		entry.Position = position
+		if length > 0 {
+			entry.Length = length
+		}
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		return videos.Put([]byte(videoID), data)
+	})
+}
+
+// InProgress returns the partially watched videos, last watched first
+func (s *Store) InProgress() ([]Entry, error) {
+	entries, err := s.Recent(0)
+	if err != nil {
+		return nil, err
+	}
+	var started []Entry
+	for _, entry := range entries {
+		if _, ok := entry.ResumePosition(); ok {
+			started = append(started, entry)
+		}
+	}
+	return started, nil
+}
+
+// Get returns the entry of a video, false if it was never watched
+func (s *Store) Get(videoID string) (Entry, bool, error) {
+	var (
+		entry Entry
+		found bool
+	)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		entry, found = getEntry(tx.Bucket(videosBucket), videoID)
+		return nil
+	})
+	return entry, found, err
+}
+
+// Recent returns the watched videos, last watched first. A limit of 0 returns them all.
+func (s *Store) Recent(limit int) ([]Entry, error) {
+	var entries []Entry
+	err := s.db.View(func(tx *bolt.Tx) error {
+		videos := tx.Bucket(videosBucket)
+		cursor := tx.Bucket(dateIndex).Cursor()
+		for key, videoID := cursor.Last(); key != nil; key, videoID = cursor.Prev() {
+			// Skip index keys left stale by an entry that was corrupted and rewritten
+			if entry, found := getEntry(videos, string(videoID)); found && bytes.Equal(key, timeKey(entry.LastWatched, entry.Video.VideoID)) {
+				entries = append(entries, entry)
+			}
+			if limit > 0 && len(entries) >= limit {
+				break
+			}
+		}
+		return nil
+	})
+	return entries, err
+}
+
+// ByChannel returns the watched videos of a channel, last watched first
+func (s *Store) ByChannel(channelID string) ([]Entry, error) {
+	var entries []Entry
+	err := s.db.View(func(tx *bolt.Tx) error {
+		videos := tx.Bucket(videosBucket)
+		prefix := channelKey(channelID, "")
+		cursor := tx.Bucket(channelIndex).Cursor()
+		for key, _ := cursor.Seek(prefix); key != nil && bytes.HasPrefix(key, prefix); key, _ = cursor.Next() {
+			if entry, found := getEntry(videos, string(key[len(prefix):])); found {
+				entries = append(entries, entry)
+			}
+		}
+		return nil
+	})
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].LastWatched.After(entries[j].LastWatched)
+	})
+	return entries, err
+}
+
+// Plays returns the plays in [from, to), oldest first. Zero times leave the range open.
+func (s *Store) Plays(from, to time.Time) ([]Play, error) {
+	var plays []Play
+	err := s.db.View(func(tx *bolt.Tx) error {
+		cursor := tx.Bucket(playsBucket).Cursor()
+		key, videoID := cursor.First()
+		if !from.IsZero() {
+			key, videoID = cursor.Seek(timePrefix(from))
+		}
+		for ; key != nil; key, videoID = cursor.Next() {
+			at := keyTime(key)
+			if !to.IsZero() && !at.Before(to) {
+				break
+			}
+			plays = append(plays, Play{VideoID: string(videoID), At: at})
+		}
+		return nil
+	})
+	return plays, err
+}
+
+// getEntry reads the entry of a video. A corrupted entry counts as missing, so that
+// listings skip it and the next play of the video overwrites it.
+func getEntry(videos *bolt.Bucket, videoID string) (Entry, bool) {
+	data := videos.Get([]byte(videoID))
+	if data == nil {
+		return Entry{}, false
+	}
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		utils.Logger.Warn("Skipping corrupted history entry.", zap.String("videoID", videoID), zap.Error(err))
+		return Entry{}, false
+	}
+	return entry, true
+}
+
+// timePrefix encodes t so that keys sort chronologically
+func timePrefix(t time.Time) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, uint64(t.UnixNano()))
+	return key
+}
+
+// timeKey makes keys unique per video for plays at the same instant
+func timeKey(t time.Time, videoID string) []byte {
+	return append(timePrefix(t), videoID...)
+}
+
+func keyTime(key []byte) time.Time {
+	return time.Unix(0, int64(binary.BigEndian.Uint64(key[:8])))
+}
+
+func channelKey(channelID, videoID string) []byte {
+	key := append([]byte(channelID), 0)
+	return append(key, videoID...)
+}
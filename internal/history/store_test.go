@@ -0,0 +1,189 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/Banh-Canh/ytui/internal/utils"
+	"github.com/Banh-Canh/ytui/pkg/youtube"
+)
+
+func TestMain(m *testing.M) {
+	// Mock zap.Logger to avoid breaking tests.
+	utils.Logger = zap.NewNop()
+
+	// Run the tests
+	m.Run()
+}
+
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+	store, err := Open(filepath.Join(t.TempDir(), DBFileName))
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func video(id, channel string) youtube.SearchResultItem {
+	return youtube.SearchResultItem{VideoID: id, Title: "Video " + id, AuthorID: channel}
+}
+
+func TestStore_DeduplicatesAndCounts(t *testing.T) {
+	store := openTestStore(t)
+	day := time.Date(2025, 3, 1, 20, 0, 0, 0, time.UTC)
+
+	require.NoError(t, store.Add(video("a", "UC1"), day))
+	require.NoError(t, store.Add(video("b", "UC2"), day.Add(time.Hour)))
+	renamed := video("a", "UC1")
+	renamed.Title = "Renamed"
+	require.NoError(t, store.Add(renamed, day.Add(2*time.Hour)))
+
+	entries, err := store.Recent(0)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "a", entries[0].Video.VideoID)
+	assert.Equal(t, "Renamed", entries[0].Video.Title)
+	assert.Equal(t, 2, entries[0].WatchCount)
+	assert.True(t, day.Equal(entries[0].FirstWatched))
+	assert.Equal(t, day.Add(2*time.Hour).Unix(), entries[0].Video.ViewedDate)
+	assert.Equal(t, "b", entries[1].Video.VideoID)
+
+	latest, err := store.Recent(1)
+	require.NoError(t, err)
+	assert.Len(t, latest, 1)
+
+	plays, err := store.Plays(day.Add(30*time.Minute), time.Time{})
+	require.NoError(t, err)
+	require.Len(t, plays, 2)
+	assert.Equal(t, "b", plays[0].VideoID)
+	assert.Equal(t, "a", plays[1].VideoID)
+}
+
+func TestStore_OlderPlayKeepsNewerMetadata(t *testing.T) {
+	store := openTestStore(t)
+	day := time.Date(2025, 3, 1, 20, 0, 0, 0, time.UTC)
+
+	current := video("a", "UC1")
+	current.Title = "Current"
+	require.NoError(t, store.Add(current, day))
+	require.NoError(t, store.Add(video("a", "UC1"), day.AddDate(0, -1, 0)))
+
+	entry, found, err := store.Get("a")
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, "Current", entry.Video.Title)
+	assert.Equal(t, 2, entry.WatchCount)
+	assert.True(t, day.AddDate(0, -1, 0).Equal(entry.FirstWatched))
+	assert.True(t, day.Equal(entry.LastWatched))
+}
+
+func TestStore_ByChannel(t *testing.T) {
+	store := openTestStore(t)
+	day := time.Date(2025, 3, 1, 20, 0, 0, 0, time.UTC)
+
+	require.NoError(t, store.Add(video("a", "UC1"), day))
+	require.NoError(t, store.Add(video("b", "UC10"), day))
+	require.NoError(t, store.Add(video("c", "UC1"), day.Add(time.Hour)))
+
+	entries, err := store.ByChannel("UC1")
+	require.NoError(t, err)
+	require.Len(t, entries, 2, "UC10 must not match the UC1 prefix")
+	assert.Equal(t, "c", entries[0].Video.VideoID)
+	assert.Equal(t, "a", entries[1].Video.VideoID)
+}
+
+func TestOpenInDir_MigratesJSONOnce(t *testing.T) {
+	dir := t.TempDir()
+	legacy := `[
+		{"videoId":"a","title":"First","vieweddate":1700000000},
+		{"videoId":"b","title":"Second","vieweddate":1700000100},
+		{"videoId":"a","title":"First again","vieweddate":1700000200}
+	]`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, LegacyFileName), []byte(legacy), 0o644))
+
+	store, err := OpenInDir(dir)
+	require.NoError(t, err)
+	entries, err := store.Recent(0)
+	require.NoError(t, err)
+	require.NoError(t, store.Close())
+
+	require.Len(t, entries, 2)
+	assert.Equal(t, "First again", entries[0].Video.Title)
+	assert.Equal(t, 2, entries[0].WatchCount)
+	assert.NoFileExists(t, filepath.Join(dir, LegacyFileName))
+	assert.FileExists(t, filepath.Join(dir, LegacyFileName+migratedSuffix))
+
+	// A second open finds nothing left to import
+	videos, err := Videos(dir)
+	require.NoError(t, err)
+	assert.Len(t, videos, 2)
+
+	// A file left behind by a failed rename is retired without being imported again
+	require.NoError(t, os.WriteFile(filepath.Join(dir, LegacyFileName), []byte(legacy), 0o644))
+	store, err = OpenInDir(dir)
+	require.NoError(t, err)
+	entry, _, err := store.Get("a")
+	require.NoError(t, err)
+	require.NoError(t, store.Close())
+	assert.Equal(t, 2, entry.WatchCount)
+	assert.NoFileExists(t, filepath.Join(dir, LegacyFileName))
+}
+
+func TestOpen_QuarantinesCorruptedDatabase(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, DBFileName)
+	require.NoError(t, os.WriteFile(path, []byte("not a database"), 0o600))
+
+	store, err := Open(path)
+	require.NoError(t, err)
+	require.NoError(t, store.Add(video("a", "UC1"), time.Now()))
+	require.NoError(t, store.Close())
+
+	quarantined, err := filepath.Glob(path + ".corrupt-*")
+	require.NoError(t, err)
+	assert.Len(t, quarantined, 1)
+}
+
+func TestMigrateJSON_RecoversTruncatedFile(t *testing.T) {
+	dir := t.TempDir()
+	// Cut off by a crash in the middle of the third entry
+	truncated := `[{"videoId":"a","vieweddate":1700000000},{"videoId":"b","vieweddate":1700000100},{"videoId":"c","vie`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, LegacyFileName), []byte(truncated), 0o644))
+
+	videos, err := Videos(dir)
+	require.NoError(t, err, "a damaged history must not fail the history view")
+	assert.Len(t, videos, 2)
+
+	quarantined, err := filepath.Glob(filepath.Join(dir, LegacyFileName+".corrupt-*"))
+	require.NoError(t, err)
+	assert.Len(t, quarantined, 1)
+	assert.NoFileExists(t, filepath.Join(dir, LegacyFileName))
+}
+
+func TestRecord_ConcurrentPlaysAreKept(t *testing.T) {
+	dir := t.TempDir()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, Record(video("a", "UC1"), Retention{}, dir))
+		}()
+	}
+	wg.Wait()
+
+	store, err := OpenInDir(dir)
+	require.NoError(t, err)
+	defer store.Close()
+	entry, found, err := store.Get("a")
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, 8, entry.WatchCount)
+}
@@ -0,0 +1,304 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+	"golang.org/x/net/html"
+
+	"github.com/Banh-Canh/ytui/pkg/youtube"
+)
+
+// TakeoutPlay is a play read from a Google Takeout export
+type TakeoutPlay struct {
+	Video youtube.SearchResultItem
+	At    time.Time
+}
+
+// Takeout is the content of a Takeout watch history
+type Takeout struct {
+	Plays []TakeoutPlay
+	// Skipped counts the records that are not plays of an available video: ads, removed
+	// videos, or dates that could not be read
+	Skipped int
+}
+
+// watchedPrefixes start the titles of Takeout records, by export language
+var watchedPrefixes = []string{"Watched ", "Vous avez regardé ", "Has visto ", "Hai guardato ", "Assistiu a "}
+
+// adsMarker is the detail of Takeout records of ads played before videos
+const adsMarker = "From Google Ads"
+
+// htmlTimeLayouts are the dates of watch-history.html in the English exports
+var htmlTimeLayouts = []string{
+	"Jan 2, 2006, 3:04:05 PM MST",
+	"2 Jan 2006, 15:04:05 MST",
+}
+
+// zoneOffsets are the UTC offsets, in hours, of the time zone abbreviations found in
+// watch-history.html that are not the local one
+var zoneOffsets = map[string]float64{
+	"UTC": 0, "GMT": 0, "WET": 0, "WEST": 1, "BST": 1, "CET": 1, "CEST": 2, "EET": 2, "EEST": 3,
+	"MSK": 3, "IST": 5.5, "SGT": 8, "HKT": 8, "JST": 9, "KST": 9,
+	"AWST": 8, "ACST": 9.5, "ACDT": 10.5, "AEST": 10, "AEDT": 11, "NZST": 12, "NZDT": 13,
+	"NST": -3.5, "NDT": -2.5, "AST": -4, "ADT": -3, "EST": -5, "EDT": -4, "CST": -6, "CDT": -5,
+	"MST": -7, "MDT": -6, "PST": -8, "PDT": -7, "AKST": -9, "AKDT": -8, "HST": -10,
+}
+
+// importWindow is how far apart two plays of a video are still taken for the same play
+// when importing: Takeout's JSON and HTML exports differ in precision, and the time
+// YouTube logs a play is not the one ytui records it at
+const importWindow = 5 * time.Minute
+
+// ParseTakeout reads Takeout's watch-history.json or watch-history.html, telling them
+// apart from their first character
+func ParseTakeout(r io.Reader) (Takeout, error) {
+	reader := bufio.NewReader(r)
+	for {
+		b, err := reader.Peek(1)
+		if err != nil {
+			return Takeout{}, fmt.Errorf("failed to read Takeout export: %w", err)
+		}
+		switch {
+		case b[0] == '[':
+			return parseTakeoutJSON(reader)
+		case b[0] == '<':
+			return parseTakeoutHTML(reader)
+		case strings.IndexByte(" \t\r\n\xef\xbb\xbf", b[0]) >= 0:
+			// Whitespace and byte order mark
+			reader.Discard(1) // nolint:errcheck
+		default:
+			return Takeout{}, fmt.Errorf("not a Takeout watch history: expected JSON or HTML")
+		}
+	}
+}
+
+type takeoutRecord struct {
+	Title     string    `json:"title"`
+	TitleURL  string    `json:"titleUrl"`
+	Time      time.Time `json:"time"`
+	Subtitles []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"subtitles"`
+	Details []struct {
+		Name string `json:"name"`
+	} `json:"details"`
+}
+
+func parseTakeoutJSON(r io.Reader) (Takeout, error) {
+	var records []takeoutRecord
+	if err := json.NewDecoder(r).Decode(&records); err != nil {
+		return Takeout{}, fmt.Errorf("failed to parse watch-history.json: %w", err)
+	}
+
+	var takeout Takeout
+	for _, record := range records {
+		isAd := false
+		for _, detail := range record.Details {
+			isAd = isAd || detail.Name == adsMarker
+		}
+		var channelName, channelURL string
+		if len(record.Subtitles) > 0 {
+			channelName, channelURL = record.Subtitles[0].Name, record.Subtitles[0].URL
+		}
+		play, ok := takeoutPlay(record.TitleURL, record.Title, channelName, channelURL, record.Time)
+		if !ok || isAd {
+			takeout.Skipped++
+			continue
+		}
+		takeout.Plays = append(takeout.Plays, play)
+	}
+	return takeout, nil
+}
+
+func parseTakeoutHTML(r io.Reader) (Takeout, error) {
+	doc, err := html.Parse(r)
+	if err != nil {
+		return Takeout{}, fmt.Errorf("failed to parse watch-history.html: %w", err)
+	}
+
+	var takeout Takeout
+	for _, cell := range findByClass(doc, "outer-cell") {
+		var content *html.Node
+		if cells := findByClass(cell, "content-cell"); len(cells) > 0 {
+			content = cells[0]
+		}
+		if content == nil {
+			continue
+		}
+		if strings.Contains(nodeText(cell), adsMarker) {
+			takeout.Skipped++
+			continue
+		}
+
+		// Watched <a video>title</a><br><a channel>name</a><br>date<br>
+		var (
+			links []*html.Node
+			texts []string
+		)
+		for child := content.FirstChild; child != nil; child = child.NextSibling {
+			switch {
+			case child.Type == html.ElementNode && child.Data == "a":
+				links = append(links, child)
+			case child.Type == html.TextNode:
+				if text := strings.TrimSpace(child.Data); text != "" {
+					texts = append(texts, text)
+				}
+			}
+		}
+		if len(links) == 0 || len(texts) == 0 {
+			takeout.Skipped++
+			continue
+		}
+
+		at, ok := parseHTMLTime(texts[len(texts)-1])
+		var channelName, channelURL string
+		if len(links) > 1 {
+			channelName, channelURL = nodeText(links[1]), attr(links[1], "href")
+		}
+		play, found := takeoutPlay(attr(links[0], "href"), nodeText(links[0]), channelName, channelURL, at)
+		if !ok || !found {
+			takeout.Skipped++
+			continue
+		}
+		takeout.Plays = append(takeout.Plays, play)
+	}
+	return takeout, nil
+}
+
+// takeoutPlay builds the play of a record, false when it is not the play of a video
+func takeoutPlay(videoURL, title, channelName, channelURL string, at time.Time) (TakeoutPlay, bool) {
+	ref, err := youtube.ParseURL(videoURL)
+	if err != nil || ref.VideoID == "" || at.IsZero() {
+		return TakeoutPlay{}, false
+	}
+	for _, prefix := range watchedPrefixes {
+		title = strings.TrimPrefix(title, prefix)
+	}
+	if title == videoURL {
+		// The title of a video that became private or was deleted, backfill may find it
+		title = ref.VideoID
+	}
+	video := youtube.SearchResultItem{
+		Type:       "video",
+		VideoID:    ref.VideoID,
+		Title:      title,
+		Author:     channelName,
+		AuthorURL:  channelURL,
+		ViewedDate: at.Unix(),
+	}
+	if channel, err := youtube.ParseURL(channelURL); err == nil {
+		video.AuthorID = channel.ChannelID
+	}
+	return TakeoutPlay{Video: video, At: at}, true
+}
+
+// parseHTMLTime reads a date of watch-history.html, in the local time zone when its
+// abbreviation is the local one. Dates in an unknown time zone are not read.
+func parseHTMLTime(value string) (time.Time, bool) {
+	value = strings.NewReplacer("\u202f", " ", "\u00a0", " ").Replace(value)
+	for _, layout := range htmlTimeLayouts {
+		at, err := time.ParseInLocation(layout, value, time.Local)
+		if err != nil {
+			continue
+		}
+		if at.Location() == time.Local || at.Location() == time.UTC {
+			return at, true
+		}
+		// Other abbreviations get a made-up zone holding the clock as if it were UTC, with
+		// a zero offset unless it is GMT+2 or the like
+		name, offset := at.Zone()
+		if offset == 0 {
+			hours, known := zoneOffsets[name]
+			if !known {
+				return time.Time{}, false
+			}
+			offset = int(hours * 3600)
+		}
+		clock := at.UTC()
+		return time.Date(clock.Year(), clock.Month(), clock.Day(), clock.Hour(), clock.Minute(), clock.Second(), 0, time.FixedZone(name, offset)), true
+	}
+	return time.Time{}, false
+}
+
+// findByClass returns the elements under n, n included, having class, without looking
+// inside the matches
+func findByClass(n *html.Node, class string) []*html.Node {
+	if n.Type == html.ElementNode {
+		for _, name := range strings.Fields(attr(n, "class")) {
+			if name == class {
+				return []*html.Node{n}
+			}
+		}
+	}
+	var found []*html.Node
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		found = append(found, findByClass(child, class)...)
+	}
+	return found
+}
+
+func nodeText(n *html.Node) string {
+	var text strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			text.WriteString(n.Data)
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(n)
+	return strings.TrimSpace(text.String())
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// Import adds the plays of a Takeout export. Plays already in the history, recorded by
+// ytui or from an earlier import of an overlapping export, are skipped: a play of the same
+// video within importWindow is taken for the same one. It returns how many were added.
+func (s *Store) Import(plays []TakeoutPlay) (int, error) {
+	added := 0
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		for _, play := range plays {
+			if hasPlayNear(tx, play.Video.VideoID, play.At) {
+				continue
+			}
+			if err := addPlay(tx, play.Video, play.At); err != nil {
+				return err
+			}
+			added++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return added, nil
+}
+
+// hasPlayNear tells whether a play of the video is logged within importWindow of at
+func hasPlayNear(tx *bolt.Tx, videoID string, at time.Time) bool {
+	end := at.Add(importWindow)
+	cursor := tx.Bucket(playsBucket).Cursor()
+	for key, id := cursor.Seek(timePrefix(at.Add(-importWindow))); key != nil && !keyTime(key).After(end); key, id = cursor.Next() {
+		if string(id) == videoID {
+			return true
+		}
+	}
+	return false
+}
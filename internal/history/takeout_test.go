@@ -0,0 +1,201 @@
+package history
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Banh-Canh/ytui/pkg/youtube"
+)
+
+const takeoutJSON = `[{
+  "header": "YouTube",
+  "title": "Watched Building a Compiler",
+  "titleUrl": "https://www.youtube.com/watch?v=aaaaaaaaaaa",
+  "subtitles": [{"name": "Gopher", "url": "https://www.youtube.com/channel/UCaaaaaaaaaaaaaaaaaaaaaa"}],
+  "time": "2024-05-02T18:30:00.123Z",
+  "products": ["YouTube"]
+}, {
+  "header": "YouTube",
+  "title": "Watched https://www.youtube.com/watch?v=bbbbbbbbbbb",
+  "titleUrl": "https://www.youtube.com/watch?v=bbbbbbbbbbb",
+  "time": "2024-05-01T10:00:00Z"
+}, {
+  "header": "YouTube",
+  "title": "Watched Some ad",
+  "titleUrl": "https://www.youtube.com/watch?v=ccccccccccc",
+  "time": "2024-05-01T09:59:00Z",
+  "details": [{"name": "From Google Ads"}]
+}, {
+  "header": "YouTube",
+  "title": "Watched a video that has been removed",
+  "time": "2024-04-30T10:00:00Z"
+}]`
+
+const takeoutHTML = "\ufeff" + `<html><body><div class="mdl-grid">
+<div class="outer-cell mdl-cell mdl-cell--12-col mdl-shadow--2dp"><div class="mdl-grid">
+<div class="header-cell mdl-cell mdl-cell--12-col"><p class="mdl-typography--title">YouTube<br></p></div>
+<div class="content-cell mdl-cell mdl-cell--6-col mdl-typography--body-1">Watched&nbsp;<a href="https://www.youtube.com/watch?v=aaaaaaaaaaa">Building a Compiler</a><br><a href="https://www.youtube.com/channel/UCaaaaaaaaaaaaaaaaaaaaaa">Gopher</a><br>May 2, 2024, 6:30:00` + "\u202f" + `PM UTC<br></div>
+<div class="content-cell mdl-cell mdl-cell--6-col mdl-typography--body-1 mdl-typography--text-right"></div>
+<div class="content-cell mdl-cell mdl-cell--12-col mdl-typography--caption"><b>Products:</b><br>&emsp;YouTube<br></div>
+</div></div>
+<div class="outer-cell mdl-cell mdl-cell--12-col mdl-shadow--2dp"><div class="mdl-grid">
+<div class="content-cell mdl-cell mdl-cell--6-col mdl-typography--body-1">Watched&nbsp;<a href="https://www.youtube.com/watch?v=ccccccccccc">Some ad</a><br>May 1, 2024, 9:59:00 AM UTC<br></div>
+<div class="content-cell mdl-cell mdl-cell--12-col mdl-typography--caption"><b>Details:</b><br>&emsp;From Google Ads<br></div>
+</div></div>
+<div class="outer-cell mdl-cell mdl-cell--12-col mdl-shadow--2dp"><div class="mdl-grid">
+<div class="content-cell mdl-cell mdl-cell--6-col mdl-typography--body-1">Watched a video that has been removed<br>Apr 30, 2024, 10:00:00 AM UTC<br></div>
+</div></div>
+</div></body></html>`
+
+func TestParseTakeout_JSON(t *testing.T) {
+	takeout, err := ParseTakeout(strings.NewReader("  " + takeoutJSON))
+	require.NoError(t, err)
+	assert.Equal(t, 2, takeout.Skipped, "ads and removed videos")
+	require.Len(t, takeout.Plays, 2)
+
+	play := takeout.Plays[0]
+	assert.Equal(t, "aaaaaaaaaaa", play.Video.VideoID)
+	assert.Equal(t, "Building a Compiler", play.Video.Title)
+	assert.Equal(t, "Gopher", play.Video.Author)
+	assert.Equal(t, "UCaaaaaaaaaaaaaaaaaaaaaa", play.Video.AuthorID)
+	assert.True(t, time.Date(2024, 5, 2, 18, 30, 0, 123e6, time.UTC).Equal(play.At))
+
+	assert.Equal(t, "bbbbbbbbbbb", takeout.Plays[1].Video.Title, "private videos are titled with their ID")
+}
+
+func TestParseTakeout_HTML(t *testing.T) {
+	takeout, err := ParseTakeout(strings.NewReader(takeoutHTML))
+	require.NoError(t, err)
+	assert.Equal(t, 2, takeout.Skipped)
+	require.Len(t, takeout.Plays, 1)
+
+	play := takeout.Plays[0]
+	assert.Equal(t, "aaaaaaaaaaa", play.Video.VideoID)
+	assert.Equal(t, "Building a Compiler", play.Video.Title)
+	assert.Equal(t, "UCaaaaaaaaaaaaaaaaaaaaaa", play.Video.AuthorID)
+	assert.Equal(t, time.Date(2024, 5, 2, 18, 30, 0, 0, time.UTC).Unix(), play.At.Unix())
+}
+
+func TestParseTakeout_Unknown(t *testing.T) {
+	_, err := ParseTakeout(strings.NewReader("videoId,title\n"))
+	assert.Error(t, err)
+}
+
+func TestStore_ImportSkipsDuplicates(t *testing.T) {
+	store := openTestStore(t)
+	takeout, err := ParseTakeout(strings.NewReader(takeoutJSON))
+	require.NoError(t, err)
+
+	added, err := store.Import(takeout.Plays)
+	require.NoError(t, err)
+	assert.Equal(t, 2, added)
+
+	added, err = store.Import(takeout.Plays)
+	require.NoError(t, err)
+	assert.Zero(t, added, "importing the same export twice")
+
+	entry, found, err := store.Get("aaaaaaaaaaa")
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, 1, entry.WatchCount)
+}
+
+func TestStore_ImportSkipsPlaysOfAnotherExport(t *testing.T) {
+	store := openTestStore(t)
+	fromJSON, err := ParseTakeout(strings.NewReader(takeoutJSON))
+	require.NoError(t, err)
+	fromHTML, err := ParseTakeout(strings.NewReader(takeoutHTML))
+	require.NoError(t, err)
+
+	added, err := store.Import(fromJSON.Plays)
+	require.NoError(t, err)
+	assert.Equal(t, 2, added)
+
+	added, err = store.Import(fromHTML.Plays)
+	require.NoError(t, err)
+	assert.Zero(t, added, "the HTML export drops the milliseconds of the JSON one")
+}
+
+func TestStore_ImportSkipsRecordedPlays(t *testing.T) {
+	store := openTestStore(t)
+	at := time.Date(2024, 5, 2, 18, 27, 0, 0, time.UTC)
+	require.NoError(t, store.Add(video("aaaaaaaaaaa", "UCaaaaaaaaaaaaaaaaaaaaaa"), at))
+
+	takeout, err := ParseTakeout(strings.NewReader(takeoutJSON))
+	require.NoError(t, err)
+	added, err := store.Import(takeout.Plays)
+	require.NoError(t, err)
+	assert.Equal(t, 1, added, "the play recorded 3 minutes earlier is the same")
+
+	entry, found, err := store.Get("aaaaaaaaaaa")
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, 1, entry.WatchCount)
+
+	// A rewatch later that day is another play
+	added, err = store.Import([]TakeoutPlay{{Video: takeout.Plays[0].Video, At: at.Add(time.Hour)}})
+	require.NoError(t, err)
+	assert.Equal(t, 1, added)
+}
+
+func TestParseHTMLTime_Zones(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Time
+		ok       bool
+	}{
+		{"May 2, 2024, 6:30:00 PM UTC", time.Date(2024, 5, 2, 18, 30, 0, 0, time.UTC), true},
+		{"May 2, 2024, 11:30:00 AM PDT", time.Date(2024, 5, 2, 18, 30, 0, 0, time.UTC), true},
+		{"2 May 2024, 20:30:00 CEST", time.Date(2024, 5, 2, 18, 30, 0, 0, time.UTC), true},
+		{"May 3, 2024, 12:00:00 AM IST", time.Date(2024, 5, 2, 18, 30, 0, 0, time.UTC), true},
+		{"May 2, 2024, 9:30:00 PM GMT+3", time.Date(2024, 5, 2, 18, 30, 0, 0, time.UTC), true},
+		{"May 2, 2024, 6:30:00 PM XYZT", time.Time{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			at, ok := parseHTMLTime(tt.value)
+			assert.Equal(t, tt.ok, ok)
+			assert.True(t, tt.expected.Equal(at), "got %s", at)
+		})
+	}
+}
+
+func TestBackfill(t *testing.T) {
+	dir := t.TempDir()
+	store, err := OpenInDir(dir)
+	require.NoError(t, err)
+	takeout, err := ParseTakeout(strings.NewReader(takeoutJSON))
+	require.NoError(t, err)
+	_, err = store.Import(takeout.Plays)
+	require.NoError(t, err)
+	require.NoError(t, store.Close())
+
+	var fetched []string
+	fetch := func(videoIDs []string) (map[string]youtube.SearchResultItem, error) {
+		fetched = append(fetched, videoIDs...)
+		// The private video can't be fetched
+		return map[string]youtube.SearchResultItem{
+			"aaaaaaaaaaa": {Title: "Building a Compiler", Author: "Gopher", AuthorID: "UCaaaaaaaaaaaaaaaaaaaaaa", LengthSeconds: 600,
+				VideoThumbnails: []youtube.VideoThumbnail{{Quality: "default"}}},
+		}, &youtube.BatchError{Errors: map[string]error{"bbbbbbbbbbb": assert.AnError}}
+	}
+	updated, err := Backfill(fetch, nil, dir)
+	require.NoError(t, err)
+	assert.Equal(t, 1, updated)
+	assert.ElementsMatch(t, []string{"aaaaaaaaaaa", "bbbbbbbbbbb"}, fetched)
+
+	store, err = OpenInDir(dir)
+	require.NoError(t, err)
+	defer store.Close()
+	entry, _, err := store.Get("aaaaaaaaaaa")
+	require.NoError(t, err)
+	assert.Equal(t, int32(600), entry.Video.LengthSeconds)
+	assert.Equal(t, entry.LastWatched.Unix(), entry.Video.ViewedDate, "the play date is kept")
+	missing, err := store.MissingMetadata()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bbbbbbbbbbb"}, missing)
+}
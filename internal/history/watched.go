@@ -0,0 +1,107 @@
+package history
+
+import (
+	"encoding/json"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+	"go.uber.org/zap"
+
+	"github.com/Banh-Canh/ytui/internal/utils"
+	"github.com/Banh-Canh/ytui/pkg/youtube"
+)
+
+// Finished reports whether the video counts as watched: played to the end or marked
+// watched, and not marked unwatched since. A play whose progress was not followed counts
+// as watched, one of a known length stopped before minResumePosition does not.
+func (e Entry) Finished() bool {
+	if e.Unwatched {
+		return false
+	}
+	followed := e.Position > 0 || e.Length > 0
+	if followed && e.length() > 0 && e.Position < minResumePosition {
+		return false
+	}
+	_, partial := e.ResumePosition()
+	return !partial
+}
+
+// SetWatched marks a video watched or unwatched without playing it and returns its
+// entry. Marking a video watched moves where it was stopped to its end, and adds it to
+// the history without a play if needed. Marking a video that isn't in the history
+// unwatched does nothing and returns false.
+func (s *Store) SetWatched(video youtube.SearchResultItem, watched bool, at time.Time) (Entry, bool, error) {
+	var (
+		entry Entry
+		found bool
+	)
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		videos := tx.Bucket(videosBucket)
+		entry, found = getEntry(videos, video.VideoID)
+		if !found {
+			if !watched {
+				return nil
+			}
+			video.ViewedDate = at.Unix()
+			entry = Entry{Video: video, FirstWatched: at, LastWatched: at}
+			if err := tx.Bucket(dateIndex).Put(timeKey(at, video.VideoID), []byte(video.VideoID)); err != nil {
+				return err
+			}
+			if err := tx.Bucket(channelIndex).Put(channelKey(video.AuthorID, video.VideoID), nil); err != nil {
+				return err
+			}
+			found = true
+		}
+
+		entry.Unwatched = !watched
+		if watched {
+			entry.Position = entry.Length
+		}
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		return videos.Put([]byte(video.VideoID), data)
+	})
+	if err != nil {
+		return Entry{}, false, err
+	}
+	return entry, found, nil
+}
+
+// Entries returns the entries of the history of a config directory by video ID
+func Entries(configDir string) (map[string]Entry, error) {
+	recent, err := fromStore(configDir, func(store *Store) ([]Entry, error) {
+		recent, err := store.Recent(0)
+		if err != nil {
+			utils.Logger.Error("Failed to read history.", zap.Error(err))
+		}
+		return recent, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	entries := make(map[string]Entry, len(recent))
+	for _, entry := range recent {
+		entries[entry.Video.VideoID] = entry
+	}
+	return entries, nil
+}
+
+// SetWatched marks a video of the history of a config directory watched or unwatched
+func SetWatched(video youtube.SearchResultItem, watched bool, configDir string) (Entry, bool, error) {
+	var entry Entry
+	var found bool
+	err := withStore(configDir, func(store *Store) error {
+		var err error
+		entry, found, err = store.SetWatched(video, watched, time.Now())
+		if err != nil {
+			utils.Logger.Error("Failed to mark video.", zap.String("videoID", video.VideoID), zap.Bool("watched", watched), zap.Error(err))
+		}
+		return err
+	})
+	if err != nil {
+		return Entry{}, false, err
+	}
+	return entry, found, nil
+}
@@ -0,0 +1,69 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Banh-Canh/ytui/pkg/youtube"
+)
+
+func TestEntry_Finished(t *testing.T) {
+	assert.True(t, Entry{}.Finished(), "played without a known position")
+	assert.False(t, Entry{Position: 3 * time.Minute, Length: 10 * time.Minute}.Finished(), "stopped midway")
+	assert.True(t, Entry{Position: 10 * time.Minute, Length: 10 * time.Minute}.Finished())
+	assert.False(t, Entry{Position: 10 * time.Second, Length: 10 * time.Minute}.Finished(), "barely started")
+	assert.False(t, Entry{Length: 10 * time.Minute}.Finished(), "closed before the first second")
+	assert.False(t, Entry{Position: 10 * time.Second, Video: youtube.SearchResultItem{LengthSeconds: 600}}.Finished(), "barely started, length from the metadata")
+	assert.True(t, Entry{Position: 10 * time.Second}.Finished(), "unknown length")
+	assert.False(t, Entry{Unwatched: true}.Finished())
+}
+
+func TestStore_SetWatched(t *testing.T) {
+	store := openTestStore(t)
+	day := time.Date(2025, 3, 1, 20, 0, 0, 0, time.UTC)
+
+	// Unknown videos stay out of the history when marked unwatched
+	_, found, err := store.SetWatched(video("a", "UC1"), false, day)
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	entry, found, err := store.SetWatched(video("a", "UC1"), true, day)
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.True(t, entry.Finished())
+	assert.Zero(t, entry.WatchCount, "marking is not a play")
+	plays, err := store.Plays(time.Time{}, time.Time{})
+	require.NoError(t, err)
+	assert.Empty(t, plays)
+	byChannel, err := store.ByChannel("UC1")
+	require.NoError(t, err)
+	assert.Len(t, byChannel, 1)
+
+	entry, _, err = store.SetWatched(video("a", "UC1"), false, day)
+	require.NoError(t, err)
+	assert.False(t, entry.Finished())
+
+	// Playing it again makes it watched
+	require.NoError(t, store.Add(video("a", "UC1"), day.Add(time.Hour)))
+	entry, _, err = store.Get("a")
+	require.NoError(t, err)
+	assert.True(t, entry.Finished())
+	assert.Equal(t, 1, entry.WatchCount)
+}
+
+func TestStore_SetWatchedForgetsPosition(t *testing.T) {
+	store := openTestStore(t)
+	require.NoError(t, store.Add(video("a", "UC1"), time.Now()))
+	require.NoError(t, store.SetPosition("a", 3*time.Minute, 10*time.Minute))
+
+	entry, _, err := store.SetWatched(video("a", "UC1"), true, time.Now())
+	require.NoError(t, err)
+	assert.True(t, entry.Finished())
+	assert.Equal(t, 1.0, entry.Fraction())
+	inProgress, err := store.InProgress()
+	require.NoError(t, err)
+	assert.Empty(t, inProgress)
+}
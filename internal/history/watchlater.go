@@ -0,0 +1,116 @@
+package history
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	bolt "go.etcd.io/bbolt"
+	"go.uber.org/zap"
+
+	"github.com/Banh-Canh/ytui/internal/utils"
+)
+
+// LegacyWatchLaterFileName is the Watch Later queue written before it moved into the
+// database
+const LegacyWatchLaterFileName = "watch_later.json"
+
+// watchLaterKey in watchLaterBucket holds the whole queue
+var watchLaterKey = []byte("queue")
+
+// WatchLater returns the Watch Later queue, nil when none was ever saved
+func (s *Store) WatchLater() ([]byte, error) {
+	var data []byte
+	err := s.db.View(func(tx *bolt.Tx) error {
+		// Values are only valid for the transaction
+		data = bytes.Clone(tx.Bucket(watchLaterBucket).Get(watchLaterKey))
+		return nil
+	})
+	return data, err
+}
+
+// UpdateWatchLater replaces the Watch Later queue by what change makes of it, nil for no
+// change. It runs in one transaction, so changes of other goroutines and ytui instances
+// are never lost.
+func (s *Store) UpdateWatchLater(change func(data []byte) ([]byte, error)) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(watchLaterBucket)
+		data, err := change(bytes.Clone(bucket.Get(watchLaterKey)))
+		if err != nil || data == nil {
+			return err
+		}
+		return bucket.Put(watchLaterKey, data)
+	})
+}
+
+// MigrateWatchLater imports the Watch Later queue of older versions, a JSON array, then
+// renames the file. A queue already in the database is kept, so a file that could not
+// be renamed is only retired on the next start. A damaged file is quarantined instead
+// of failing every start.
+func (s *Store) MigrateWatchLater(path string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read legacy Watch Later: %w", err)
+	}
+
+	var items []json.RawMessage
+	if parseErr := json.Unmarshal(data, &items); parseErr != nil {
+		quarantined, err := quarantine(path)
+		if err != nil {
+			return fmt.Errorf("failed to quarantine damaged legacy Watch Later: %w", err)
+		}
+		utils.Logger.Warn("Legacy Watch Later is damaged, starting an empty queue.",
+			zap.String("quarantined", quarantined), zap.Error(parseErr))
+		return nil
+	}
+
+	alreadyMigrated := false
+	err = s.UpdateWatchLater(func(stored []byte) ([]byte, error) {
+		if stored != nil {
+			alreadyMigrated = true
+			return nil, nil
+		}
+		return data, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to import legacy Watch Later: %w", err)
+	}
+	if err := os.Rename(path, path+migratedSuffix); err != nil {
+		return fmt.Errorf("failed to retire legacy Watch Later: %w", err)
+	}
+	if alreadyMigrated {
+		utils.Logger.Warn("Watch Later is already in the database, retired the legacy file.", zap.String("filename", path))
+		return nil
+	}
+	utils.Logger.Info("Migrated Watch Later to database.", zap.String("filename", path), zap.Int("videos", len(items)))
+	return nil
+}
+
+// WatchLater returns the Watch Later queue of a config directory
+func WatchLater(configDir string) ([]byte, error) {
+	return fromStore(configDir, func(store *Store) ([]byte, error) {
+		data, err := store.WatchLater()
+		if err != nil {
+			utils.Logger.Error("Failed to read Watch Later.", zap.Error(err))
+			return nil, err
+		}
+		return data, nil
+	})
+}
+
+// UpdateWatchLater replaces the Watch Later queue of a config directory by what change
+// makes of it
+func UpdateWatchLater(change func(data []byte) ([]byte, error), configDir string) error {
+	return withStore(configDir, func(store *Store) error {
+		if err := store.UpdateWatchLater(change); err != nil {
+			utils.Logger.Error("Failed to update Watch Later.", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+}
@@ -0,0 +1,48 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_WatchLaterSurvivesClear(t *testing.T) {
+	store := openTestStore(t)
+	data, err := store.WatchLater()
+	require.NoError(t, err)
+	assert.Nil(t, data, "never saved")
+
+	require.NoError(t, store.UpdateWatchLater(func([]byte) ([]byte, error) { return []byte(`["a"]`), nil }))
+	require.NoError(t, store.UpdateWatchLater(func(data []byte) ([]byte, error) {
+		assert.Equal(t, `["a"]`, string(data))
+		return nil, nil
+	}))
+	require.NoError(t, store.Clear())
+
+	data, err = store.WatchLater()
+	require.NoError(t, err)
+	assert.Equal(t, `["a"]`, string(data))
+}
+
+func TestOpenInDir_MigratesWatchLaterOnce(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, LegacyWatchLaterFileName)
+	require.NoError(t, os.WriteFile(path, []byte(`["a"]`), 0o600))
+
+	data, err := WatchLater(dir)
+	require.NoError(t, err)
+	assert.Equal(t, `["a"]`, string(data))
+	assert.NoFileExists(t, path)
+	assert.FileExists(t, path+migratedSuffix)
+
+	// A file left behind by a failed rename is retired without replacing the queue
+	require.NoError(t, UpdateWatchLater(func([]byte) ([]byte, error) { return []byte(`[]`), nil }, dir))
+	require.NoError(t, os.WriteFile(path, []byte(`["a"]`), 0o600))
+	data, err = WatchLater(dir)
+	require.NoError(t, err)
+	assert.Equal(t, `[]`, string(data))
+	assert.NoFileExists(t, path)
+}
@@ -0,0 +1,85 @@
+package player
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"time"
+)
+
+// Progress is how far a playback got
+type Progress struct {
+	Position time.Duration
+	Duration time.Duration
+}
+
+const (
+	// progressInterval is how often OnProgress is called while playing
+	progressInterval = 10 * time.Second
+	// connectRetry is the wait between attempts to reach the socket mpv is creating
+	connectRetry = 200 * time.Millisecond
+)
+
+// ipcEvent is the part of mpv's JSON IPC messages used to follow the playback
+type ipcEvent struct {
+	Event string   `json:"event"`
+	Name  string   `json:"name"`
+	Data  *float64 `json:"data"`
+}
+
+// observeCommands ask mpv to send every change of the playback position and length
+var observeCommands = []string{
+	`{"command":["observe_property",1,"time-pos"]}`,
+	`{"command":["observe_property",2,"duration"]}`,
+}
+
+// trackProgress follows the playback over mpv's JSON IPC socket until mpv exits and
+// returns the last progress seen. report, if set, is called at most every progressInterval.
+func trackProgress(socketPath string, exited <-chan struct{}, report func(Progress)) Progress {
+	var conn net.Conn
+	for conn == nil {
+		var err error
+		conn, err = dialIPC(socketPath)
+		if err == nil {
+			break
+		}
+		select {
+		case <-exited:
+			return Progress{}
+		case <-time.After(connectRetry):
+		}
+	}
+	defer conn.Close()
+
+	for _, command := range observeCommands {
+		if _, err := conn.Write([]byte(command + "\n")); err != nil {
+			return Progress{}
+		}
+	}
+
+	var (
+		progress   Progress
+		lastReport time.Time
+	)
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		var event ipcEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil || event.Event != "property-change" || event.Data == nil {
+			// Replies to commands, other events, and properties unset while loading or quitting
+			continue
+		}
+		value := time.Duration(*event.Data * float64(time.Second))
+		switch event.Name {
+		case "time-pos":
+			progress.Position = value
+		case "duration":
+			progress.Duration = value
+		}
+		if report != nil && time.Since(lastReport) >= progressInterval {
+			lastReport = time.Now()
+			report(progress)
+		}
+	}
+	// mpv closes the socket when it quits
+	return progress
+}
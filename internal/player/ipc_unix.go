@@ -0,0 +1,22 @@
+//go:build !windows
+
+package player
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+)
+
+// newIPCPath returns a socket path for mpv's --input-ipc-server and its cleanup
+func newIPCPath() (string, func(), error) {
+	dir, err := os.MkdirTemp("", "ytui-mpv-")
+	if err != nil {
+		return "", nil, err
+	}
+	return filepath.Join(dir, "mpv.sock"), func() { os.RemoveAll(dir) }, nil
+}
+
+func dialIPC(path string) (net.Conn, error) {
+	return net.Dial("unix", path)
+}
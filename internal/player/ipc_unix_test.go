@@ -0,0 +1,74 @@
+//go:build !windows
+
+package player
+
+import (
+	"bufio"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serveIPC plays mpv on a unix socket: it answers one connection by checking the observe
+// commands and sending the messages, then closes it
+func serveIPC(t *testing.T, socketPath string, messages ...string) <-chan []string {
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	received := make(chan []string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			close(received)
+			return
+		}
+		defer conn.Close()
+
+		var commands []string
+		scanner := bufio.NewScanner(conn)
+		for len(commands) < len(observeCommands) && scanner.Scan() {
+			commands = append(commands, scanner.Text())
+		}
+		received <- commands
+		conn.Write([]byte(strings.Join(messages, "\n") + "\n"))
+	}()
+	return received
+}
+
+func TestTrackProgress(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "mpv.sock")
+	received := serveIPC(t, socketPath,
+		`{"request_id":0,"error":"success"}`,
+		`{"event":"property-change","id":1,"name":"time-pos","data":null}`,
+		`{"event":"property-change","id":1,"name":"time-pos","data":12.5}`,
+		`{"event":"property-change","id":2,"name":"duration","data":600}`,
+		`{"event":"playback-restart"}`,
+		`not json`,
+	)
+
+	var reports []Progress
+	progress := trackProgress(socketPath, make(chan struct{}), func(progress Progress) {
+		reports = append(reports, progress)
+	})
+
+	assert.Equal(t, observeCommands, <-received)
+	assert.Equal(t, Progress{Position: 12500 * time.Millisecond, Duration: 10 * time.Minute}, progress)
+	// Later changes wait for progressInterval
+	assert.Equal(t, []Progress{{Position: 12500 * time.Millisecond}}, reports)
+}
+
+func TestTrackProgress_MPVExitedBeforeTheSocket(t *testing.T) {
+	exited := make(chan struct{})
+	close(exited)
+
+	progress := trackProgress(filepath.Join(t.TempDir(), "mpv.sock"), exited, func(Progress) {
+		t.Error("unexpected report")
+	})
+	assert.Equal(t, Progress{}, progress)
+}
@@ -0,0 +1,19 @@
+//go:build windows
+
+package player
+
+import (
+	"errors"
+	"net"
+)
+
+// errNoIPC disables progress tracking: mpv serves its IPC on a named pipe on Windows
+var errNoIPC = errors.New("mpv IPC is not supported on Windows")
+
+func newIPCPath() (string, func(), error) {
+	return "", nil, errNoIPC
+}
+
+func dialIPC(string) (net.Conn, error) {
+	return nil, errNoIPC
+}
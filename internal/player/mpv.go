@@ -1,25 +1,136 @@
 package player
 
 import (
+	"fmt"
+	"os"
 	"os/exec"
+	"sync"
+	"time"
 
 	"go.uber.org/zap"
 
 	"github.com/Banh-Canh/ytui/internal/utils"
 )
 
-func RunMPV(videoPath string) {
+// Options tunes a playback
+type Options struct {
+	// Segments are skipped or marked as chapters while playing
+	Segments []Segment
+	// Start is where playback begins, zero for the beginning
+	Start time.Duration
+	// Live is set for streams that are live right now
+	Live bool
+	// LiveFromStart plays a live stream from its beginning instead of the live edge
+	LiveFromStart bool
+	// OnProgress, if set, receives the playback position every few seconds while playing
+	OnProgress func(Progress)
+	// Incognito keeps the play out of the YouTube account's watch history
+	Incognito bool
+}
+
+const (
+	// defaultFormat picks separate DASH streams, which live streams only offer from their start
+	defaultFormat = "bestvideo[ext=mp4][height<=?2160]+bestaudio[ext=m4a]"
+	// liveFormat picks the muxed HLS streams served at the live edge
+	liveFormat     = "best[height<=?2160]/best"
+	ytdlRawOptions = "cookies-from-browser=firefox"
+	// markWatched has yt-dlp add the play to the watch history of the browser's account
+	markWatched = "mark-watched="
+)
+
+// baseArgs returns the mpv options choosing formats for the kind of video
+func baseArgs(options Options) []string {
+	format, rawOptions := defaultFormat, ytdlRawOptions
+	if !options.Incognito {
+		rawOptions = markWatched + "," + rawOptions
+	}
+	if options.Live {
+		if options.LiveFromStart {
+			rawOptions += ",live-from-start="
+		} else {
+			format = liveFormat
+		}
+	}
+	return []string{"--ytdl-format=" + format, "--ytdl-raw-options=" + rawOptions}
+}
+
+// running holds the mpv processes started by RunMPV, for KillAll
+var (
+	runningMu sync.Mutex
+	running   = make(map[*exec.Cmd]struct{})
+)
+
+// RunMPV plays videoPath and blocks until mpv exits. The playback position is followed
+// over mpv's JSON IPC socket and the last one is returned; it stays zero when mpv could
+// not be started or reached.
+func RunMPV(videoPath string, options Options) (Progress, error) {
 	utils.Logger.Debug("Starting the video with mpv...")
-	args := []string{
-		"--ytdl-format=bestvideo[ext=mp4][height<=?2160]+bestaudio[ext=m4a]",
-		"--ytdl-raw-options=mark-watched=,cookies-from-browser=firefox",
-		videoPath, // Path to the video file
+	args := baseArgs(options)
+
+	if options.Start > 0 {
+		args = append(args, fmt.Sprintf("--start=%d", int(options.Start.Seconds())))
 	}
-	cmd := exec.Command("mpv", args...)
-	err := cmd.Start()
+
+	if len(options.Segments) > 0 {
+		scriptPath, err := writeSegmentsScript(options.Segments)
+		if err != nil {
+			utils.Logger.Error("Failed to write segments script, playing without it.", zap.Error(err))
+		} else {
+			// mpv reads the script while running, remove it once playback is over
+			defer os.Remove(scriptPath)
+			args = append(args, "--script="+scriptPath)
+		}
+	}
+
+	ipcPath, removeIPC, err := newIPCPath()
 	if err != nil {
-		utils.Logger.Error("Failed to start mpv.", zap.Error(err))
+		utils.Logger.Debug("Playing without progress tracking.", zap.Error(err))
 	} else {
-		utils.Logger.Info("Mpv started.")
+		defer removeIPC()
+		args = append(args, "--input-ipc-server="+ipcPath)
+	}
+	args = append(args, videoPath) // Path to the video file
+
+	cmd := exec.Command("mpv", args...)
+	if err := cmd.Start(); err != nil {
+		utils.Logger.Error("Failed to start mpv.", zap.Error(err))
+		return Progress{}, err
+	}
+	utils.Logger.Info("Mpv started.", zap.Int("segments", len(options.Segments)), zap.Bool("live", options.Live))
+
+	runningMu.Lock()
+	running[cmd] = struct{}{}
+	runningMu.Unlock()
+	defer func() {
+		runningMu.Lock()
+		delete(running, cmd)
+		runningMu.Unlock()
+	}()
+
+	exited := make(chan struct{})
+	tracked := make(chan Progress, 1)
+	go func() {
+		if ipcPath == "" {
+			tracked <- Progress{}
+			return
+		}
+		tracked <- trackProgress(ipcPath, exited, options.OnProgress)
+	}()
+
+	waitErr := cmd.Wait()
+	close(exited)
+	progress := <-tracked
+	utils.Logger.Info("Mpv exited.", zap.Duration("position", progress.Position), zap.Duration("duration", progress.Duration))
+	return progress, waitErr
+}
+
+// KillAll kills the mpv processes still running, for when ytui exits
+func KillAll() {
+	runningMu.Lock()
+	defer runningMu.Unlock()
+	for cmd := range running {
+		if cmd.Process != nil {
+			cmd.Process.Kill() // nolint:errcheck
+		}
 	}
 }
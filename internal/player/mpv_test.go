@@ -0,0 +1,52 @@
+package player
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBaseArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  Options
+		expected []string
+	}{
+		{
+			"video",
+			Options{},
+			[]string{"--ytdl-format=" + defaultFormat, "--ytdl-raw-options=mark-watched=,cookies-from-browser=firefox"},
+		},
+		{
+			"incognito",
+			Options{Incognito: true},
+			[]string{"--ytdl-format=" + defaultFormat, "--ytdl-raw-options=cookies-from-browser=firefox"},
+		},
+		{
+			"live",
+			Options{Live: true},
+			[]string{"--ytdl-format=" + liveFormat, "--ytdl-raw-options=mark-watched=,cookies-from-browser=firefox"},
+		},
+		{
+			"live from the start",
+			Options{Live: true, LiveFromStart: true},
+			[]string{"--ytdl-format=" + defaultFormat, "--ytdl-raw-options=mark-watched=,cookies-from-browser=firefox,live-from-start="},
+		},
+		{
+			"incognito live",
+			Options{Live: true, Incognito: true},
+			[]string{"--ytdl-format=" + liveFormat, "--ytdl-raw-options=cookies-from-browser=firefox"},
+		},
+		{
+			"start offset is not a format option",
+			Options{Start: 90 * time.Second},
+			[]string{"--ytdl-format=" + defaultFormat, "--ytdl-raw-options=mark-watched=,cookies-from-browser=firefox"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, baseArgs(tt.options))
+		})
+	}
+}
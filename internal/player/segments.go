@@ -0,0 +1,76 @@
+package player
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Segment is a time range of the video, in seconds, that mpv skips or marks as chapters
+type Segment struct {
+	Start    float64
+	End      float64
+	Category string
+	Skip     bool
+}
+
+// segmentsScript is the mpv Lua script handling segments. The %s verb receives the
+// segments as a Lua table.
+const segmentsScript = `local segments = %s
+
+mp.register_event("file-loaded", function()
+    local chapters = mp.get_property_native("chapter-list") or {}
+    local added = false
+    for _, s in ipairs(segments) do
+        if not s.skip then
+            table.insert(chapters, {title = "[SponsorBlock] " .. s.category, time = s.start})
+            table.insert(chapters, {title = "[SponsorBlock] end of " .. s.category, time = s.stop})
+            added = true
+        end
+    end
+    if added then
+        table.sort(chapters, function(a, b) return a.time < b.time end)
+        mp.set_property_native("chapter-list", chapters)
+    end
+end)
+
+local skipped = {}
+mp.observe_property("time-pos", "number", function(_, pos)
+    if pos == nil then
+        return
+    end
+    for i, s in ipairs(segments) do
+        if s.skip and not skipped[i] and pos >= s.start and pos < s.stop - 0.5 then
+            skipped[i] = true
+            mp.set_property_number("time-pos", s.stop)
+            mp.osd_message("Skipped " .. s.category)
+        end
+    end
+end)
+`
+
+// renderSegmentsScript returns the Lua script for the given segments
+func renderSegmentsScript(segments []Segment) string {
+	var table strings.Builder
+	table.WriteString("{\n")
+	for _, s := range segments {
+		fmt.Fprintf(&table, "    {start = %g, stop = %g, category = %q, skip = %t},\n", s.Start, s.End, s.Category, s.Skip)
+	}
+	table.WriteString("}")
+	return fmt.Sprintf(segmentsScript, table.String())
+}
+
+// writeSegmentsScript writes the Lua script to a temporary file and returns its path
+func writeSegmentsScript(segments []Segment) (string, error) {
+	file, err := os.CreateTemp("", "ytui_segments_*.lua")
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(renderSegmentsScript(segments)); err != nil {
+		os.Remove(file.Name())
+		return "", err
+	}
+	return file.Name(), nil
+}
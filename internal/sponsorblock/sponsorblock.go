@@ -0,0 +1,151 @@
+// Package sponsorblock looks up crowd-sourced segments (sponsors, intros, ...) of YouTube videos.
+package sponsorblock
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultServerURL is the public SponsorBlock server
+const DefaultServerURL = "https://sponsor.ajay.app"
+
+// hashPrefixLength is how many hex characters of the video ID hash are sent to the server
+const hashPrefixLength = 4
+
+// skipActionType marks the segments to jump over. Other action types, such as mute,
+// cover video content the player must not skip.
+const skipActionType = "skip"
+
+// Action is what the player does with the segments of a category
+type Action string
+
+const (
+	ActionSkip    Action = "skip"
+	ActionChapter Action = "chapter"
+	ActionIgnore  Action = "ignore"
+)
+
+// DefaultActions maps every category to its default action
+func DefaultActions() map[string]string {
+	return map[string]string{
+		"sponsor":        string(ActionSkip),
+		"selfpromo":      string(ActionSkip),
+		"interaction":    string(ActionSkip),
+		"intro":          string(ActionChapter),
+		"outro":          string(ActionChapter),
+		"preview":        string(ActionChapter),
+		"filler":         string(ActionChapter),
+		"music_offtopic": string(ActionChapter),
+	}
+}
+
+// Segment is a time range of a video in seconds
+type Segment struct {
+	Category   string
+	ActionType string
+	UUID       string
+	Start      float64
+	End        float64
+}
+
+type Client struct {
+	serverURL  string
+	httpClient *http.Client
+}
+
+// NewClient returns a client for the given server, DefaultServerURL when empty
+func NewClient(serverURL string) *Client {
+	if serverURL == "" {
+		serverURL = DefaultServerURL
+	}
+	return &Client{
+		serverURL:  strings.TrimRight(serverURL, "/"),
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type hashedVideo struct {
+	VideoID  string `json:"videoID"`
+	Segments []struct {
+		Segment    [2]float64 `json:"segment"`
+		Category   string     `json:"category"`
+		ActionType string     `json:"actionType"`
+		UUID       string     `json:"UUID"`
+	} `json:"segments"`
+}
+
+// Segments returns the segments of a video in the given categories meant to be skipped,
+// leaving out the ones to mute. Only a prefix of the SHA-256 hash of the video ID is
+// sent, so the server does not learn which video is played.
+func (c *Client) Segments(ctx context.Context, videoID string, categories []string) ([]Segment, error) {
+	if len(categories) == 0 {
+		return nil, nil
+	}
+
+	categoriesJSON, err := json.Marshal(categories)
+	if err != nil {
+		return nil, err
+	}
+	query := url.Values{}
+	query.Set("categories", string(categoriesJSON))
+	query.Set("actionTypes", `["`+skipActionType+`"]`)
+	requestURL := fmt.Sprintf("%s/api/skipSegments/%s?%s", c.serverURL, hashPrefix(videoID), query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query SponsorBlock: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// The server answers 404 when no video with the prefix has segments
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("SponsorBlock returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var videos []hashedVideo
+	if err := json.NewDecoder(resp.Body).Decode(&videos); err != nil {
+		return nil, fmt.Errorf("failed to decode SponsorBlock response: %w", err)
+	}
+
+	var segments []Segment
+	for _, video := range videos {
+		if video.VideoID != videoID {
+			continue
+		}
+		for _, s := range video.Segments {
+			// Servers predating action types send none, meaning skip
+			if s.ActionType != skipActionType && s.ActionType != "" {
+				continue
+			}
+			segments = append(segments, Segment{
+				Category:   s.Category,
+				ActionType: s.ActionType,
+				UUID:       s.UUID,
+				Start:      s.Segment[0],
+				End:        s.Segment[1],
+			})
+		}
+	}
+	return segments, nil
+}
+
+func hashPrefix(videoID string) string {
+	sum := sha256.Sum256([]byte(videoID))
+	return hex.EncodeToString(sum[:])[:hashPrefixLength]
+}
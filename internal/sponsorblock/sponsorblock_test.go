@@ -0,0 +1,94 @@
+package sponsorblock
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSegments_SendsOnlyHashPrefix(t *testing.T) {
+	var gotPath, gotCategories string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotCategories = r.URL.Query().Get("categories")
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]map[string]interface{}{
+			{
+				"videoID": "dQw4w9WgXcQ",
+				"segments": []map[string]interface{}{
+					{"segment": []float64{1.5, 20}, "category": "sponsor", "actionType": "skip", "UUID": "a"},
+					{"segment": []float64{100, 110}, "category": "outro", "actionType": "skip", "UUID": "b"},
+				},
+			},
+			{
+				// Another video sharing the hash prefix must be ignored
+				"videoID":  "other",
+				"segments": []map[string]interface{}{{"segment": []float64{0, 5}, "category": "sponsor"}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	segments, err := NewClient(server.URL+"/").Segments(context.Background(), "dQw4w9WgXcQ", []string{"sponsor", "outro"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "/api/skipSegments/"+hashPrefix("dQw4w9WgXcQ"), gotPath)
+	assert.NotContains(t, gotPath, "dQw4w9WgXcQ")
+	assert.Equal(t, `["sponsor","outro"]`, gotCategories)
+	require.Len(t, segments, 2)
+	assert.Equal(t, Segment{Category: "sponsor", ActionType: "skip", UUID: "a", Start: 1.5, End: 20}, segments[0])
+	assert.Equal(t, "outro", segments[1].Category)
+}
+
+func TestSegments_LeavesOutMuteSegments(t *testing.T) {
+	var gotActionTypes string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotActionTypes = r.URL.Query().Get("actionTypes")
+		// A server answering with every action type anyway
+		json.NewEncoder(w).Encode([]map[string]interface{}{{
+			"videoID": "dQw4w9WgXcQ",
+			"segments": []map[string]interface{}{
+				{"segment": []float64{10, 20}, "category": "sponsor", "actionType": "mute", "UUID": "muted"},
+				{"segment": []float64{30, 40}, "category": "sponsor", "actionType": "skip", "UUID": "skipped"},
+			},
+		}})
+	}))
+	defer server.Close()
+
+	segments, err := NewClient(server.URL).Segments(context.Background(), "dQw4w9WgXcQ", []string{"sponsor"})
+	require.NoError(t, err)
+	assert.Equal(t, `["skip"]`, gotActionTypes)
+	require.Len(t, segments, 1)
+	assert.Equal(t, "skipped", segments[0].UUID)
+}
+
+func TestSegments_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	segments, err := NewClient(server.URL).Segments(context.Background(), "dQw4w9WgXcQ", []string{"sponsor"})
+	require.NoError(t, err)
+	assert.Empty(t, segments)
+}
+
+func TestSegments_ServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "overloaded", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	_, err := NewClient(server.URL).Segments(context.Background(), "dQw4w9WgXcQ", []string{"sponsor"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "503")
+}
+
+func TestHashPrefix(t *testing.T) {
+	prefix := hashPrefix("dQw4w9WgXcQ")
+	assert.Len(t, prefix, hashPrefixLength)
+	assert.Equal(t, prefix, hashPrefix("dQw4w9WgXcQ"))
+}
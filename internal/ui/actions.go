@@ -0,0 +1,135 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+
+	"github.com/Banh-Canh/ytui/internal/utils"
+	"github.com/Banh-Canh/ytui/pkg/youtube"
+)
+
+// statusMsg reports the outcome of a background action in the footer
+type statusMsg struct {
+	text string
+	err  error
+}
+
+var errWriteDisabled = errors.New("write actions are disabled, set youtube.write: true in config")
+
+// authenticateForWrite makes sure the client carries a token with the write scope.
+// A token granted read-only triggers a new consent in the browser.
+func authenticateForWrite(yt *youtube.YouTube, settings *viper.Viper) error {
+	if !settings.GetBool("youtube.write") {
+		return errWriteDisabled
+	}
+	return yt.Authenticate()
+}
+
+func writeError(action string, err error) statusMsg {
+	if errors.Is(err, youtube.ErrInsufficientScope) {
+		err = fmt.Errorf("%w, log out with 'ytui auth logout' and log in again", err)
+	}
+	utils.Logger.Error("Write action failed.", zap.String("action", action), zap.Error(err))
+	return statusMsg{err: fmt.Errorf("%s failed: %w", action, err)}
+}
+
+// setSubscription subscribes to or unsubscribes from the channel of a video. With local
+// subscriptions the channel list in config is edited, otherwise the account is changed.
+func setSubscription(yt *youtube.YouTube, video youtube.SearchResultItem, subscribe bool, p profile) tea.Cmd {
+	return func() tea.Msg {
+		action := "Unsubscribe"
+		if subscribe {
+			action = "Subscribe"
+		}
+		if video.AuthorID == "" {
+			return statusMsg{err: fmt.Errorf("%s failed: no channel ID for %q", action, video.Title)}
+		}
+
+		if p.settings.GetBool("channels.local") {
+			if err := setLocalSubscription(video.AuthorID, subscribe, p.settings); err != nil {
+				return writeError(action, err)
+			}
+		} else {
+			if err := authenticateForWrite(yt, p.settings); err != nil {
+				return writeError(action, err)
+			}
+			var err error
+			if subscribe {
+				_, err = yt.Subscriptions().Subscribe(video.AuthorID)
+			} else {
+				err = yt.Subscriptions().Unsubscribe(video.AuthorID)
+			}
+			if err != nil {
+				return writeError(action, err)
+			}
+		}
+
+		utils.Logger.Info("Subscription changed.", zap.String("channelID", video.AuthorID), zap.Bool("subscribed", subscribe))
+		if subscribe {
+			return statusMsg{text: "Subscribed to " + video.Author}
+		}
+		return statusMsg{text: "Unsubscribed from " + video.Author}
+	}
+}
+
+func setLocalSubscription(channelID string, subscribe bool, settings *viper.Viper) error {
+	channels := settings.GetStringSlice("channels.subscribed")
+	index := slices.Index(channels, channelID)
+	switch {
+	case subscribe && index >= 0, !subscribe && index < 0:
+		return nil
+	case subscribe:
+		channels = append(channels, channelID)
+	default:
+		channels = slices.Delete(channels, index, index+1)
+	}
+	settings.Set("channels.subscribed", channels)
+	return settings.WriteConfig()
+}
+
+func rateVideo(yt *youtube.YouTube, video youtube.SearchResultItem, rating youtube.Rating, settings *viper.Viper) tea.Cmd {
+	return func() tea.Msg {
+		if err := authenticateForWrite(yt, settings); err != nil {
+			return writeError("Rating", err)
+		}
+		if err := yt.Videos().Rate(video.VideoID, rating); err != nil {
+			return writeError("Rating", err)
+		}
+		if rating == youtube.RatingLike {
+			return statusMsg{text: "Liked " + video.Title}
+		}
+		return statusMsg{text: "Disliked " + video.Title}
+	}
+}
+
+func addToPlaylist(yt *youtube.YouTube, video youtube.SearchResultItem, playlistInput string, settings *viper.Viper) tea.Cmd {
+	return func() tea.Msg {
+		playlistID := parsePlaylistInput(playlistInput)
+		if playlistID == "" {
+			return statusMsg{err: errors.New("add to playlist failed: no playlist ID given")}
+		}
+		if err := authenticateForWrite(yt, settings); err != nil {
+			return writeError("Add to playlist", err)
+		}
+		if _, err := yt.Playlists().AddVideo(playlistID, video.VideoID); err != nil {
+			return writeError("Add to playlist", err)
+		}
+		return statusMsg{text: fmt.Sprintf("Added %s to playlist %s", video.Title, playlistID)}
+	}
+}
+
+// parsePlaylistInput accepts a bare playlist ID or any URL carrying a list= parameter
+func parsePlaylistInput(input string) string {
+	input = strings.TrimSpace(input)
+	if parsed, err := url.Parse(input); err == nil && parsed.Query().Get("list") != "" {
+		return parsed.Query().Get("list")
+	}
+	return input
+}
@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Banh-Canh/ytui/internal/format"
+	"github.com/Banh-Canh/ytui/pkg/youtube"
+)
+
+// videoBadge returns the list badge of live streams and upcoming premieres
+func videoBadge(video youtube.SearchResultItem, now time.Time) string {
+	switch {
+	case video.LiveNow:
+		return "● LIVE"
+	case video.IsUpcoming:
+		if start, ok := video.Premiere(); ok {
+			if start.After(now) {
+				return "UPCOMING in " + format.Countdown(start.Sub(now))
+			}
+			return "UPCOMING, starting"
+		}
+		return "UPCOMING"
+	case video.IsShort:
+		return "SHORT"
+	}
+	return ""
+}
+
+// publishedLabel renders the publication date relative to now with the day, falling
+// back to the instance's text when the timestamp is unknown
+func publishedLabel(video youtube.SearchResultItem, now time.Time) string {
+	published := video.PublishedAt()
+	if published.IsZero() {
+		return video.PublishedText
+	}
+	return format.Relative(published, now) + " (" + format.Date(published) + ")"
+}
+
+// viewsLabel renders the compact view count, falling back to the instance's text
+func viewsLabel(video youtube.SearchResultItem) string {
+	if video.ViewCount <= 0 {
+		return video.ViewCountText
+	}
+	return format.ViewCount(video.ViewCount) + " views"
+}
+
+// sortFeedByDate orders live streams first, then published videos newest first, then
+// upcoming ones soonest first. Premieres carry their future start as publication date
+// and would otherwise stay on top.
+func sortFeedByDate(videos []youtube.SearchResultItem) {
+	rank := func(video youtube.SearchResultItem) int {
+		switch {
+		case video.LiveNow:
+			return 0
+		case video.IsUpcoming:
+			return 2
+		}
+		return 1
+	}
+	sort.SliceStable(videos, func(i, j int) bool {
+		ri, rj := rank(videos[i]), rank(videos[j])
+		if ri != rj {
+			return ri < rj
+		}
+		if ri == 2 {
+			return videos[i].PremiereTimestamp < videos[j].PremiereTimestamp
+		}
+		return videos[i].Published > videos[j].Published
+	})
+}
+
+// withoutShorts drops Shorts
+func withoutShorts(videos []youtube.SearchResultItem) []youtube.SearchResultItem {
+	filtered := make([]youtube.SearchResultItem, 0, len(videos))
+	for _, video := range videos {
+		if !video.IsShort {
+			filtered = append(filtered, video)
+		}
+	}
+	return filtered
+}
+
+// withoutUpcoming drops upcoming premieres and streams
+func withoutUpcoming(videos []youtube.SearchResultItem) []youtube.SearchResultItem {
+	filtered := make([]youtube.SearchResultItem, 0, len(videos))
+	for _, video := range videos {
+		if !video.IsUpcoming {
+			filtered = append(filtered, video)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/Banh-Canh/ytui/internal/utils"
+	"github.com/Banh-Canh/ytui/pkg/youtube"
+)
+
+func TestMain(m *testing.M) {
+	// Mock zap.Logger to avoid breaking tests.
+	utils.Logger = zap.NewNop()
+
+	// Run the tests
+	m.Run()
+}
+
+func TestVideoBadge(t *testing.T) {
+	now := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "● LIVE", videoBadge(youtube.SearchResultItem{LiveNow: true}, now))
+	assert.Equal(t, "", videoBadge(youtube.SearchResultItem{}, now))
+	assert.Equal(t, "UPCOMING", videoBadge(youtube.SearchResultItem{IsUpcoming: true}, now))
+	assert.Equal(t, "SHORT", videoBadge(youtube.SearchResultItem{IsShort: true}, now))
+
+	upcoming := youtube.SearchResultItem{IsUpcoming: true, PremiereTimestamp: now.Add(26*time.Hour + 30*time.Minute).Unix()}
+	assert.Equal(t, "UPCOMING in 1d 2h", videoBadge(upcoming, now))
+	upcoming.PremiereTimestamp = now.Add(-time.Minute).Unix()
+	assert.Equal(t, "UPCOMING, starting", videoBadge(upcoming, now))
+}
+
+func TestSortFeedByDate(t *testing.T) {
+	videos := []youtube.SearchResultItem{
+		{VideoID: "old", Published: 100},
+		{VideoID: "premiere-later", IsUpcoming: true, Published: 900, PremiereTimestamp: 900},
+		{VideoID: "new", Published: 200},
+		{VideoID: "premiere-soon", IsUpcoming: true, Published: 500, PremiereTimestamp: 500},
+		{VideoID: "live", LiveNow: true, Published: 50},
+	}
+	sortFeedByDate(videos)
+
+	var order []string
+	for _, video := range videos {
+		order = append(order, video.VideoID)
+	}
+	assert.Equal(t, []string{"live", "new", "old", "premiere-soon", "premiere-later"}, order)
+	assert.Len(t, withoutUpcoming(videos), 3)
+	assert.Len(t, withoutShorts([]youtube.SearchResultItem{{IsShort: true}, {}}), 1)
+}
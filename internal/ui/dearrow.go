@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+
+	"github.com/Banh-Canh/ytui/internal/dearrow"
+	"github.com/Banh-Canh/ytui/internal/utils"
+	"github.com/Banh-Canh/ytui/pkg/youtube"
+)
+
+// brandingCache holds DeArrow branding by video ID. An entry exists once a lookup started.
+type brandingCache map[string]dearrow.Branding
+
+type brandingLoadedMsg struct {
+	branding map[string]dearrow.Branding
+	// failed lists the videos whose lookup failed, to be looked up again later
+	failed []string
+}
+
+// newDeArrowClient returns nil when DeArrow is disabled
+func newDeArrowClient() *dearrow.Client {
+	if !viper.GetBool("dearrow.enable") {
+		return nil
+	}
+	return dearrow.NewClient(viper.GetString("dearrow.server"))
+}
+
+// loadBranding looks up the videos around the visible part of the list in one batch
+func (m *model) loadBranding() tea.Cmd {
+	if m.dearrow == nil || m.originalTitles || len(m.items) == 0 {
+		return nil
+	}
+
+	// Look one screen ahead so scrolling finds titles ready
+	start := m.viewportOffset
+	end := start + 2*m.viewport
+	if end > len(m.items) {
+		end = len(m.items)
+	}
+	if start >= end {
+		return nil
+	}
+	var videoIDs []string
+	for _, item := range m.items[start:end] {
+		video, ok := item.(youtube.SearchResultItem)
+		if !ok {
+			continue
+		}
+		if _, requested := m.branding[video.VideoID]; !requested {
+			m.branding[video.VideoID] = dearrow.Branding{}
+			videoIDs = append(videoIDs, video.VideoID)
+		}
+	}
+	if len(videoIDs) == 0 {
+		return nil
+	}
+
+	client := m.dearrow
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+		branding, err := client.Branding(ctx, videoIDs)
+		if err != nil {
+			utils.Logger.Debug("Failed to fetch DeArrow branding.", zap.Int("videos", len(videoIDs)), zap.Error(err))
+		}
+		var failed []string
+		for _, videoID := range videoIDs {
+			if _, ok := branding[videoID]; !ok {
+				failed = append(failed, videoID)
+			}
+		}
+		return brandingLoadedMsg{branding: branding, failed: failed}
+	}
+}
+
+// displayTitle returns the DeArrow title of a video unless original titles are shown
+func (m model) displayTitle(video youtube.SearchResultItem) string {
+	if m.dearrow == nil || m.originalTitles {
+		return video.Title
+	}
+	if branding := m.branding[video.VideoID]; branding.Title != "" {
+		return branding.Title
+	}
+	return video.Title
+}
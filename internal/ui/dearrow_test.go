@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Banh-Canh/ytui/internal/dearrow"
+	"github.com/Banh-Canh/ytui/pkg/youtube"
+)
+
+func TestLoadBranding_RetriesFailedLookups(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "overloaded", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	m := model{viewport: 10, branding: make(brandingCache), dearrow: dearrow.NewClient(server.URL)}
+	m.items = []interface{}{youtube.SearchResultItem{VideoID: "aaaaaaaaaaa", Title: "Original"}}
+
+	cmd := m.loadBranding()
+	require.NotNil(t, cmd)
+	assert.Contains(t, m.branding, "aaaaaaaaaaa", "looked up once at a time")
+	assert.Nil(t, m.loadBranding())
+
+	msg, ok := cmd().(brandingLoadedMsg)
+	require.True(t, ok)
+	assert.Equal(t, []string{"aaaaaaaaaaa"}, msg.failed)
+
+	next, _ := m.Update(msg)
+	m = next.(model)
+	assert.NotContains(t, m.branding, "aaaaaaaaaaa")
+	assert.NotNil(t, m.loadBranding(), "looked up again")
+}
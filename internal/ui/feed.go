@@ -0,0 +1,131 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/viper"
+
+	"github.com/Banh-Canh/ytui/internal/format"
+	"github.com/Banh-Canh/ytui/internal/history"
+	"github.com/Banh-Canh/ytui/pkg/youtube"
+)
+
+// feedVisits holds when the videos of the subscriptions feed were last seen by channel ID
+type feedVisits map[string]time.Time
+
+type feedVisitMsg struct {
+	seen feedVisits
+}
+
+// feedChannels returns the IDs of the channels of videos, once each
+func feedChannels(videos []youtube.SearchResultItem) []string {
+	var channelIDs []string
+	listed := make(map[string]bool)
+	for _, video := range videos {
+		if video.AuthorID != "" && !listed[video.AuthorID] {
+			listed[video.AuthorID] = true
+			channelIDs = append(channelIDs, video.AuthorID)
+		}
+	}
+	return channelIDs
+}
+
+// visitFeed reads the previous visit of the channels of the subscriptions feed. The
+// visit itself is recorded when leaving the feed or catching up.
+func visitFeed(videos []youtube.SearchResultItem, p profile) tea.Cmd {
+	return func() tea.Msg {
+		if !p.settings.GetBool("history.enable") {
+			return nil
+		}
+		configDir, err := p.dir()
+		if err != nil {
+			return nil
+		}
+		seen, err := history.LastSeen(feedChannels(videos), configDir)
+		if err != nil {
+			// The feed works without new markers
+			return nil
+		}
+		return feedVisitMsg{seen}
+	}
+}
+
+// markFeedSeen records that the videos of the channels published until at were seen
+func markFeedSeen(channelIDs []string, at time.Time, p profile) error {
+	configDir, err := p.dir()
+	if err != nil {
+		return err
+	}
+	return history.MarkSeen(at, channelIDs, configDir)
+}
+
+func catchUp(videos []youtube.SearchResultItem, at time.Time, p profile) tea.Cmd {
+	return func() tea.Msg {
+		channelIDs := feedChannels(videos)
+		if err := markFeedSeen(channelIDs, at, p); err != nil {
+			return statusMsg{err: fmt.Errorf("couldn't catch up: %w", err)}
+		}
+		return statusMsg{text: fmt.Sprintf("Caught up on %d channel(s)", len(channelIDs))}
+	}
+}
+
+// recordsFeed reports whether visits of the subscriptions feed are recorded, like plays
+func (m model) recordsFeed() bool {
+	return !m.incognito && viper.GetBool("history.enable")
+}
+
+// leaveFeed records the visit of the subscriptions feed when leaving it: the videos shown
+// are seen. It returns nil outside of a loaded feed and when visits are not recorded.
+func (m model) leaveFeed() tea.Cmd {
+	if m.currentView != SubscribedView || m.feedShownAt.IsZero() || !m.recordsFeed() {
+		return nil
+	}
+	channelIDs, shownAt, p := feedChannels(m.videoItems), m.feedShownAt, m.profile
+	return func() tea.Msg {
+		// On failure the next visit shows the same videos as new
+		markFeedSeen(channelIDs, shownAt, p) // nolint:errcheck
+		return nil
+	}
+}
+
+// isNew reports whether video was published since the previous visit of its channel in
+// the feed. Nothing is new in channels never visited, and upcoming videos are not new
+// until they are published.
+func (m model) isNew(video youtube.SearchResultItem) bool {
+	seen, ok := m.feedSeen[video.AuthorID]
+	return ok && !video.IsUpcoming && video.PublishedAt().After(seen)
+}
+
+// newCount counts the new videos of the feed, of channelID only unless it is empty
+func (m model) newCount(channelID string) int {
+	count := 0
+	for _, video := range m.videoItems {
+		if (channelID == "" || video.AuthorID == channelID) && m.isNew(video) {
+			count++
+		}
+	}
+	return count
+}
+
+// newLabel renders the new videos of a channel since its previous visit, such as
+// 3 since 2 days ago, empty when the channel was never visited
+func (m model) newLabel(channelID string, now time.Time) string {
+	seen, ok := m.feedSeen[channelID]
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("%d since %s", m.newCount(channelID), format.Relative(seen, now))
+}
+
+// catchUpFeed marks every video of the feed seen
+func (m *model) catchUpFeed(now time.Time) tea.Cmd {
+	if m.feedSeen == nil {
+		m.feedSeen = make(feedVisits)
+	}
+	for _, channelID := range feedChannels(m.videoItems) {
+		m.feedSeen[channelID] = now
+	}
+	return catchUp(m.videoItems, now, m.profile)
+}
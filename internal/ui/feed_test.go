@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Banh-Canh/ytui/internal/history"
+	"github.com/Banh-Canh/ytui/pkg/youtube"
+)
+
+func TestFeedNewVideos(t *testing.T) {
+	visit := time.Date(2025, 3, 1, 20, 0, 0, 0, time.UTC)
+	m := model{currentView: SubscribedView, videoItems: []youtube.SearchResultItem{
+		{VideoID: "aaaaaaaaaaa", AuthorID: "UC1", Published: visit.Add(time.Hour).Unix()},
+		{VideoID: "bbbbbbbbbbb", AuthorID: "UC1", Published: visit.Add(-time.Hour).Unix()},
+		{VideoID: "ccccccccccc", AuthorID: "UC1", Published: visit.Add(48 * time.Hour).Unix(), IsUpcoming: true},
+		{VideoID: "ddddddddddd", AuthorID: "UC2", Published: visit.Add(2 * time.Hour).Unix()},
+	}}
+	m.feedSeen = feedVisits{"UC1": visit}
+
+	assert.True(t, m.isNew(m.videoItems[0]))
+	assert.False(t, m.isNew(m.videoItems[1]), "published before the visit")
+	assert.False(t, m.isNew(m.videoItems[2]), "not published yet")
+	assert.False(t, m.isNew(m.videoItems[3]), "channel never visited")
+	assert.Equal(t, 1, m.newCount(""))
+	assert.Equal(t, "1 since 2 hours ago", m.newLabel("UC1", visit.Add(2*time.Hour)))
+	assert.Empty(t, m.newLabel("UC2", visit))
+
+	m.catchUpFeed(visit.Add(3 * time.Hour))
+	assert.Zero(t, m.newCount(""))
+	assert.Equal(t, []string{"UC1", "UC2"}, feedChannels(m.videoItems))
+	assert.Len(t, m.feedSeen, 2)
+}
+
+func TestLeaveFeed(t *testing.T) {
+	viper.Set("history.enable", true)
+	t.Cleanup(func() { viper.Set("history.enable", nil) })
+	shownAt := time.Date(2025, 3, 1, 20, 0, 0, 0, time.UTC)
+	videos := []youtube.SearchResultItem{{VideoID: "aaaaaaaaaaa", AuthorID: "UC1"}}
+
+	m := model{currentView: SubscribedView, videoItems: videos}
+	assert.Nil(t, m.leaveFeed(), "left before the feed was loaded")
+
+	m.feedShownAt = shownAt
+	assert.NotNil(t, m.leaveFeed())
+
+	m.incognito = true
+	assert.Nil(t, m.leaveFeed(), "incognito")
+
+	m = model{currentView: SubscribedView, videoItems: videos, feedShownAt: shownAt}
+	viper.Set("history.enable", false)
+	assert.Nil(t, m.leaveFeed(), "history off")
+
+	m.currentView = SearchResultsView
+	assert.Nil(t, m.leaveFeed())
+}
+
+func TestLeaveFeed_RecordsInItsProfile(t *testing.T) {
+	viper.Set("history.enable", true)
+	t.Cleanup(func() { viper.Set("history.enable", nil) })
+	shownAt := time.Date(2025, 3, 1, 20, 0, 0, 0, time.UTC)
+	configDir := t.TempDir()
+	m := model{
+		currentView: SubscribedView,
+		videoItems:  []youtube.SearchResultItem{{VideoID: "aaaaaaaaaaa", AuthorID: "UC1"}},
+		feedShownAt: shownAt,
+		profile:     profile{configDir: configDir, settings: viper.GetViper()},
+	}
+
+	cmd := m.leaveFeed()
+	// Switching profiles before the command runs doesn't move the visit
+	m.profile = profile{configDir: t.TempDir(), settings: viper.New()}
+	require.NotNil(t, cmd)
+	cmd()
+
+	seen, err := history.LastSeen([]string{"UC1"}, configDir)
+	require.NoError(t, err)
+	assert.True(t, shownAt.Equal(seen["UC1"]), "got %v", seen["UC1"])
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/ktr0731/go-fuzzyfinder"
 	"go.uber.org/zap"
 
+	"github.com/Banh-Canh/ytui/internal/format"
 	"github.com/Banh-Canh/ytui/internal/utils"
 	"github.com/Banh-Canh/ytui/pkg/youtube"
 )
@@ -66,41 +67,24 @@ func fetchDescriptionsInBackground(
 			ProxyURL:     proxyURLString,
 		}
 		yt := youtube.New(config)
-		searchService := yt.Search()
-		
-		for {
-			for _, video := range videoData {
-				utils.Logger.Debug("Fetching video description...", zap.String("videoTitle", video.Title))
-				videoID := video.VideoID
-				cacheLock.RLock()
-				_, found := descriptionCache[videoID]
-				cacheLock.RUnlock()
-				
-				if found {
-					utils.Logger.Debug("Video description found in cache, skipping...", zap.String("videoTitle", video.Title))
-					continue
-				}
-
-				if video.Description != "" {
-					utils.Logger.Debug("Video description already exists in query, using it...", zap.String("videoTitle", video.Title))
-					cacheLock.Lock()
-					descriptionCache[videoID] = cleanDescription(video.Description)
-					cacheLock.Unlock()
-					continue
-				}
-
-				// Fetch the video description with retries
-				if err := fetchAndCacheDescription(video, descriptionCache, cacheLock, searchService); err != nil {
-					utils.Logger.Error("Failed to fetch description.", zap.Error(err))
-					continue
-				}
+
+		var missing []string
+		cacheLock.Lock()
+		for _, video := range videoData {
+			if _, found := descriptionCache[video.VideoID]; found {
+				continue
 			}
-			
-			// Check if all descriptions are fetched
-			if allDescriptionsFetched(videoData, descriptionCache) {
-				break
+			if video.Description != "" {
+				// Search results already carry the description
+				descriptionCache[video.VideoID] = cleanDescription(video.Description)
+				continue
 			}
+			missing = append(missing, video.VideoID)
 		}
+		cacheLock.Unlock()
+
+		fetchDescriptions(yt.Search(), missing, descriptionCache, cacheLock)
+		utils.Logger.Info("Finished fetching all query's video descriptions.")
 	}()
 }
 
@@ -123,40 +107,42 @@ func cleanDescription(description string) string {
 	return strings.TrimSpace(description)
 }
 
-func fetchAndCacheDescription(
-	video youtube.SearchResultItem,
+// maxDescriptionAttempts bounds the retries of a description lookup
+const maxDescriptionAttempts = 3
+
+// fetchDescriptions looks the videos up in batches, retrying the failed ones. Videos that
+// still fail are cached with an empty description rather than retried forever.
+func fetchDescriptions(
+	searchService *youtube.SearchService,
+	videoIDs []string,
 	descriptionCache map[string]string,
 	cacheLock *sync.RWMutex,
-	searchService *youtube.SearchService,
-) error {
-	for {
-		videoInfo, err := searchService.VideoInfo(video.VideoID)
+) {
+	for attempt := 1; attempt <= maxDescriptionAttempts && len(videoIDs) > 0; attempt++ {
+		videos, err := searchService.VideoInfoBatch(videoIDs)
 		if err != nil {
-			utils.Logger.Info("Fetching description failed. Retrying...", zap.String("videoTitle", video.Title), zap.Error(err))
-			continue
+			utils.Logger.Info("Fetching descriptions failed.", zap.Int("attempt", attempt), zap.Error(err))
 		}
-		
-		description := cleanDescription(videoInfo.Description)
+
+		var failed []string
 		cacheLock.Lock()
-		descriptionCache[video.VideoID] = description
-		cacheLock.Unlock()
-		
-		if descriptionCache[video.VideoID] != "" || description == "" {
-			break
+		for _, videoID := range videoIDs {
+			video, ok := videos[videoID]
+			if !ok {
+				failed = append(failed, videoID)
+				continue
+			}
+			descriptionCache[videoID] = cleanDescription(video.Description)
 		}
+		cacheLock.Unlock()
+		videoIDs = failed
 	}
-	utils.Logger.Debug("Fetched description successfully.", zap.String("videoTitle", video.Title))
-	return nil
-}
 
-func allDescriptionsFetched(videoData []youtube.SearchResultItem, descriptionCache map[string]string) bool {
-	for _, video := range videoData {
-		if _, found := descriptionCache[video.VideoID]; !found {
-			return false
-		}
+	cacheLock.Lock()
+	for _, videoID := range videoIDs {
+		descriptionCache[videoID] = ""
 	}
-	utils.Logger.Info("Finished fetching all query's video descriptions.")
-	return true
+	cacheLock.Unlock()
 }
 
 func getVideoPreview(video youtube.SearchResultItem, descriptionCache map[string]string, cacheLock *sync.RWMutex) string {
@@ -167,7 +153,9 @@ func getVideoPreview(video youtube.SearchResultItem, descriptionCache map[string
 	description, found := descriptionCache[videoID]
 	cacheLock.RUnlock()
 	
-	duration := time.Duration(video.LengthSeconds) * time.Second
+	duration := format.Duration(video.Length())
+	published := publishedLabel(video, time.Now())
+	views := viewsLabel(video)
 
 	if !found {
 		// If not cached, show a "fetching" message
@@ -175,9 +163,9 @@ func getVideoPreview(video youtube.SearchResultItem, descriptionCache map[string
 			"=========\n\nTitle: %s\nAuthor: %s\nPublished: %s\nDuration: %s\nViews: %s\nURL: %s\n\n=========\n\nDescription: Loading...",
 			video.Title,
 			video.Author,
-			video.PublishedText,
-			duration.String(),
-			video.ViewCountText,
+			published,
+			duration,
+			views,
 			"https://www.youtube.com/watch?v="+videoID,
 		)
 	}
@@ -187,9 +175,9 @@ func getVideoPreview(video youtube.SearchResultItem, descriptionCache map[string
 		"=========\n\nTitle: %s\nAuthor: %s\nPublished: %s\nDuration: %s\nViews: %s\nURL: %s\n\n=========\n\nDescription: \n\n%s",
 		video.Title,
 		video.Author,
-		video.PublishedText,
-		duration.String(),
-		video.ViewCountText,
+		published,
+		duration,
+		views,
 		"https://www.youtube.com/watch?v="+videoID,
 		description,
 	)
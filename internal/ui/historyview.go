@@ -0,0 +1,234 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Banh-Canh/ytui/internal/history"
+	"github.com/Banh-Canh/ytui/pkg/youtube"
+)
+
+// historyBrowser holds the filters and the selection of HistoryView
+type historyBrowser struct {
+	filter       history.Filter
+	channelName  string          // Name of the channel filtered on
+	editing      bool            // Whether keys type into the text filter
+	dateRange    int             // Index of the date filter in dateRanges
+	selected     map[string]bool // IDs of the videos selected for removal
+	undo         []history.Removed
+	confirmClear bool // Whether X was pressed once and awaits confirmation
+}
+
+// dateRange is a last-watched filter of HistoryView, cycled with F
+type dateRange struct {
+	label string
+	since func(now time.Time) time.Time
+}
+
+var dateRanges = []dateRange{
+	{label: "", since: func(time.Time) time.Time { return time.Time{} }},
+	{label: "today", since: func(now time.Time) time.Time {
+		year, month, day := now.Date()
+		return time.Date(year, month, day, 0, 0, 0, 0, now.Location())
+	}},
+	{label: "last 7 days", since: func(now time.Time) time.Time { return now.AddDate(0, 0, -7) }},
+	{label: "last 30 days", since: func(now time.Time) time.Time { return now.AddDate(0, 0, -30) }},
+	{label: "last year", since: func(now time.Time) time.Time { return now.AddDate(-1, 0, 0) }},
+}
+
+type historyRemovedMsg struct {
+	removed history.Removed
+}
+
+type historyRestoredMsg struct {
+	restored history.Removed
+}
+
+func removeFromHistory(videoIDs []string, p profile) tea.Cmd {
+	return func() tea.Msg {
+		configDir, err := p.dir()
+		if err != nil {
+			return statusMsg{err: err}
+		}
+		removed, err := history.Remove(videoIDs, configDir)
+		if err != nil {
+			return statusMsg{err: fmt.Errorf("couldn't remove from history: %w", err)}
+		}
+		return historyRemovedMsg{removed}
+	}
+}
+
+func restoreToHistory(removed history.Removed, p profile) tea.Cmd {
+	return func() tea.Msg {
+		configDir, err := p.dir()
+		if err != nil {
+			return statusMsg{err: err}
+		}
+		if err := history.Restore(removed, configDir); err != nil {
+			return statusMsg{err: fmt.Errorf("couldn't restore history: %w", err)}
+		}
+		return historyRestoredMsg{removed}
+	}
+}
+
+// filtered returns the videos passing the filters
+func (b historyBrowser) filtered(videos []youtube.SearchResultItem) []youtube.SearchResultItem {
+	if b.filter.IsZero() {
+		return videos
+	}
+	var matched []youtube.SearchResultItem
+	for _, video := range videos {
+		if b.filter.Match(video) {
+			matched = append(matched, video)
+		}
+	}
+	return matched
+}
+
+// title describes the filters and the selection, appended to the view's title
+func (b historyBrowser) title() string {
+	var title string
+	if b.editing || b.filter.Query != "" {
+		title += " [filter: " + b.filter.Query
+		if b.editing {
+			title += "▏"
+		}
+		title += "]"
+	}
+	if b.filter.Channel != "" {
+		title += " (channel: " + b.channelName + ")"
+	}
+	if label := dateRanges[b.dateRange].label; label != "" {
+		title += " (" + label + ")"
+	}
+	if len(b.selected) > 0 {
+		title += fmt.Sprintf(" (%d selected)", len(b.selected))
+	}
+	return title
+}
+
+// handleHistoryKey handles the keys specific to HistoryView, false when the key is
+// left to the common bindings
+func (m model) handleHistoryKey(msg tea.KeyMsg) (model, tea.Cmd, bool) {
+	browser := &m.historyBrowse
+	key := msg.String()
+	if key != "X" {
+		browser.confirmClear = false
+	}
+
+	if browser.editing {
+		switch key {
+		case "enter":
+			browser.editing = false
+		case "esc":
+			browser.editing = false
+			browser.filter.Query = ""
+			m.showVideos()
+		case "backspace":
+			if query := []rune(browser.filter.Query); len(query) > 0 {
+				browser.filter.Query = string(query[:len(query)-1])
+				m.showVideos()
+			}
+		case "ctrl+c":
+			return m, tea.Quit, true
+		default:
+			switch msg.Type {
+			case tea.KeyRunes:
+				browser.filter.Query += string(msg.Runes)
+				m.showVideos()
+			case tea.KeySpace:
+				browser.filter.Query += " "
+				m.showVideos()
+			}
+		}
+		return m, nil, true
+	}
+
+	switch key {
+	case "f":
+		browser.editing = true
+	case "c":
+		// Toggle the filter on the selected video's channel
+		if browser.filter.Channel != "" {
+			browser.filter.Channel, browser.channelName = "", ""
+		} else if m.currentDetails != nil {
+			browser.filter.Channel, browser.channelName = m.currentDetails.AuthorID, m.currentDetails.Author
+		}
+		m.showVideos()
+	case "F":
+		browser.dateRange = (browser.dateRange + 1) % len(dateRanges)
+		browser.filter.From = dateRanges[browser.dateRange].since(time.Now())
+		m.showVideos()
+	case "v":
+		if m.currentDetails == nil {
+			return m, nil, true
+		}
+		if browser.selected == nil {
+			browser.selected = make(map[string]bool)
+		}
+		if videoID := m.currentDetails.VideoID; browser.selected[videoID] {
+			delete(browser.selected, videoID)
+		} else {
+			browser.selected[videoID] = true
+		}
+		if m.cursor < len(m.items)-1 {
+			m.cursor++
+			m.updateViewport()
+			m.updateCurrentDetails()
+		}
+	case "x":
+		videoIDs := make([]string, 0, len(browser.selected))
+		for videoID := range browser.selected {
+			videoIDs = append(videoIDs, videoID)
+		}
+		if len(videoIDs) == 0 && m.currentDetails != nil {
+			videoIDs = append(videoIDs, m.currentDetails.VideoID)
+		}
+		if len(videoIDs) == 0 {
+			return m, nil, true
+		}
+		return m, removeFromHistory(videoIDs, m.profile), true
+	case "z":
+		if len(browser.undo) == 0 {
+			m.status = "✗ nothing to undo"
+			return m, nil, true
+		}
+		last := browser.undo[len(browser.undo)-1]
+		browser.undo = browser.undo[:len(browser.undo)-1]
+		return m, restoreToHistory(last, m.profile), true
+	case "X":
+		if !browser.confirmClear {
+			browser.confirmClear = true
+			m.status = "Press X again to clear the whole history"
+			return m, nil, true
+		}
+		browser.confirmClear = false
+		videoIDs := make([]string, 0, len(m.videoItems))
+		for _, video := range m.videoItems {
+			videoIDs = append(videoIDs, video.VideoID)
+		}
+		return m, removeFromHistory(videoIDs, m.profile), true
+	default:
+		return m, nil, false
+	}
+	return m, nil, true
+}
+
+// dropVideos removes videos from the list, keeping the cursor where it was
+func (m *model) dropVideos(removed history.Removed) {
+	gone := make(map[string]bool, removed.Len())
+	for _, entry := range removed.Entries {
+		gone[entry.Video.VideoID] = true
+		delete(m.historyBrowse.selected, entry.Video.VideoID)
+	}
+	kept := make([]youtube.SearchResultItem, 0, len(m.videoItems))
+	for _, video := range m.videoItems {
+		if !gone[video.VideoID] {
+			kept = append(kept, video)
+		}
+	}
+	m.videoItems = kept
+	m.reshowVideos()
+}
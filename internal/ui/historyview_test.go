@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Banh-Canh/ytui/internal/history"
+	"github.com/Banh-Canh/ytui/pkg/youtube"
+)
+
+func historyModel() model {
+	m := model{currentView: HistoryView, width: 80, height: 24, videoItems: []youtube.SearchResultItem{
+		{VideoID: "aaaaaaaaaaa", Title: "Go generics", Author: "Gopher", AuthorID: "UC1"},
+		{VideoID: "bbbbbbbbbbb", Title: "Rust traits", Author: "Crab", AuthorID: "UC2"},
+		{VideoID: "ccccccccccc", Title: "Go channels", Author: "Gopher", AuthorID: "UC1"},
+	}}
+	m.showVideos()
+	return m
+}
+
+func pressKeys(t *testing.T, m model, keys ...tea.KeyMsg) model {
+	t.Helper()
+	for _, key := range keys {
+		var handled bool
+		m, _, handled = m.handleHistoryKey(key)
+		require.True(t, handled, key.String())
+	}
+	return m
+}
+
+func runes(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestHistoryBrowser_Filters(t *testing.T) {
+	m := pressKeys(t, historyModel(), runes("f"), runes("g"), runes("o"), runes(" "), tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Len(t, m.items, 2, "incremental text filter")
+	assert.False(t, m.historyBrowse.editing)
+	assert.Contains(t, m.historyBrowse.title(), "[filter: go ]")
+
+	m = pressKeys(t, m, runes("f"), tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Len(t, m.items, 3, "esc clears the text filter")
+
+	m.cursor = 1
+	m.updateCurrentDetails()
+	m = pressKeys(t, m, runes("c"))
+	assert.Len(t, m.items, 1, "channel of the selected video")
+	m = pressKeys(t, m, runes("c"))
+	assert.Len(t, m.items, 3)
+
+	_, _, handled := m.handleHistoryKey(runes("p"))
+	assert.False(t, handled, "common bindings stay available")
+}
+
+func TestHistoryBrowser_SelectAndDrop(t *testing.T) {
+	m := historyModel()
+	m.updateCurrentDetails()
+	m = pressKeys(t, m, runes("v"), runes("v"))
+	assert.Len(t, m.historyBrowse.selected, 2)
+	assert.Equal(t, 2, m.cursor, "selecting moves down")
+
+	m.dropVideos(history.Removed{Entries: []history.Entry{
+		{Video: youtube.SearchResultItem{VideoID: "aaaaaaaaaaa"}},
+		{Video: youtube.SearchResultItem{VideoID: "bbbbbbbbbbb"}},
+	}})
+	require.Len(t, m.items, 1)
+	assert.Empty(t, m.historyBrowse.selected)
+	assert.Equal(t, "ccccccccccc", m.currentDetails.VideoID)
+}
+
+func TestHistoryBrowser_ClearNeedsConfirmation(t *testing.T) {
+	m, cmd, _ := historyModel().handleHistoryKey(runes("X"))
+	assert.Nil(t, cmd)
+	assert.True(t, m.historyBrowse.confirmClear)
+
+	m, cmd, _ = m.handleHistoryKey(runes("j"))
+	assert.Nil(t, cmd)
+	assert.False(t, m.historyBrowse.confirmClear, "any other key cancels")
+}
@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Banh-Canh/ytui/pkg/youtube"
+)
+
+// startTimeCache holds the playback start of videos by ID
+type startTimeCache map[string]time.Duration
+
+// linkLoadedMsg carries the videos of a video or channel URL typed in the search box
+type linkLoadedMsg struct {
+	title string
+	items []youtube.SearchResultItem
+}
+
+// openLink switches to the view matching a URL typed in the search box
+func (m model) openLink(ref youtube.Ref) (model, tea.Cmd) {
+	m.searchQuery = ""
+	m.loading = true
+	m.nextPageToken = ""
+	m.loadingMore = false
+
+	if ref.Kind == youtube.RefPlaylist {
+		m.currentView = PlaylistVideosView
+		m.playlistID = ref.PlaylistID
+		m.playlistTitle = "Playlist"
+		m.publicPlaylist = true
+		m.savedPlaylists = nil
+		return m, loadPublicPlaylist(m.yt, ref.PlaylistID, "", false)
+	}
+
+	if ref.Kind == youtube.RefVideo && ref.Start > 0 {
+		// Keep the timestamp of the link for playback
+		m.startTimes[ref.VideoID] = ref.Start
+	}
+	m.currentView = LinkView
+	return m, loadLink(m.yt, ref)
+}
+
+func loadLink(yt *youtube.YouTube, ref youtube.Ref) tea.Cmd {
+	return func() tea.Msg {
+		ref, err := yt.Search().Resolve(ref)
+		if err != nil {
+			return errMsg{err}
+		}
+
+		switch ref.Kind {
+		case youtube.RefVideo:
+			video, err := yt.GetVideoInfo(ref.VideoID)
+			if err != nil {
+				return errMsg{err}
+			}
+			return linkLoadedMsg{title: "Video", items: []youtube.SearchResultItem{video}}
+		case youtube.RefChannel:
+			videos, err := yt.Search().Videos(youtube.SearchOptions{Query: ref.ChannelID, Subscription: true})
+			if err != nil {
+				return errMsg{err}
+			}
+			title := "Channel"
+			if len(videos) > 0 {
+				title = "Channel: " + videos[0].Author
+			}
+			return linkLoadedMsg{title: title, items: videos}
+		}
+		return errMsg{fmt.Errorf("cannot open %s links", ref.Kind)}
+	}
+}
+
+func loadPublicPlaylist(yt *youtube.YouTube, playlistID, pageToken string, appendPage bool) tea.Cmd {
+	return func() tea.Msg {
+		playlist, page, err := yt.Search().Playlist(playlistID, pageToken)
+		if err != nil {
+			return errMsg{err}
+		}
+		return videoPageMsg{title: playlist.Title, items: page.Items, nextPageToken: page.NextPageToken, appendPage: appendPage}
+	}
+}
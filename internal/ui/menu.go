@@ -1,14 +1,13 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"os/exec"
 	"os/signal"
-	"path/filepath"
-	"sort"
 	"strings"
 	"syscall"
 	"time"
@@ -20,7 +19,10 @@ import (
 	"github.com/blacktop/go-termimg"
 
 	"github.com/Banh-Canh/ytui/internal/config"
+	"github.com/Banh-Canh/ytui/internal/credentials"
+	"github.com/Banh-Canh/ytui/internal/dearrow"
 	"github.com/Banh-Canh/ytui/internal/download"
+	"github.com/Banh-Canh/ytui/internal/format"
 	"github.com/Banh-Canh/ytui/internal/history"
 	"github.com/Banh-Canh/ytui/internal/player"
 	"github.com/Banh-Canh/ytui/internal/utils"
@@ -35,6 +37,15 @@ const (
 	SubscribedView
 	HistoryView
 	SearchInputView
+	ProfilesView
+	PlaylistInputView
+	PlaylistsView
+	PlaylistVideosView
+	LinkView
+	ShortsView
+	ContinueWatchingView
+	StatsView
+	WatchLaterView
 )
 
 type menuItem struct {
@@ -45,6 +56,8 @@ type menuItem struct {
 
 type model struct {
 	yt              *youtube.YouTube
+	store           credentials.Store
+	profile         profile // Taken by commands, see currentProfile
 	currentView     ViewType
 	items           []interface{} // Can be menuItem or youtube.SearchResultItem
 	videoItems      []youtube.SearchResultItem
@@ -60,6 +73,33 @@ type model struct {
 	selectedVideo   *youtube.SearchResultItem
 	thumbnailCache  map[string]string // Cache for rendered thumbnails
 	sortByDate      bool              // Whether to sort by date (newest first)
+	status          string            // Outcome of the last background action, shown in the footer
+	playlistInput   string            // Playlist ID or URL typed in PlaylistInputView
+	returnView      ViewType          // View to go back to when leaving an input view
+	nextPageToken   string            // Token of the next page of a paged view, empty on the last page
+	loadingMore     bool              // Whether the next page is being fetched
+	playlistID      string            // Playlist shown in PlaylistVideosView
+	playlistTitle   string            // Title of the playlist shown in PlaylistVideosView
+	savedPlaylists  *savedList        // Playlists view to restore when leaving a playlist
+	publicPlaylist  bool              // Whether the playlist comes from a URL and is read from Invidious
+	linkTitle       string            // Title of LinkView
+	startTimes      startTimeCache    // Playback start of videos opened from a timestamped URL
+	hideUpcoming    bool              // Whether upcoming premieres are hidden from the subscriptions feed
+	hideShorts      bool              // Whether Shorts are hidden from the subscriptions feed and search
+	segments        segmentCache      // SponsorBlock segments of the videos shown in the detail pane
+	dearrow         *dearrow.Client   // DeArrow lookups, nil when disabled
+	branding        brandingCache     // DeArrow titles of the listed videos
+	originalTitles  bool              // Whether DeArrow titles are toggled off
+	videoInfo       videoInfoCache    // Full metadata of listed videos that came without it
+	progress        progressCache     // History entries of the videos in ContinueWatchingView
+	historyBrowse   historyBrowser    // Filters and selection of HistoryView
+	stats           *history.Stats    // Statistics shown in StatsView, nil until loaded
+	statsRange      int               // Index of the period of StatsView in statsRanges
+	incognito       bool              // Whether plays are kept out of every history for this session
+	watched         progressCache     // History entries of the listed videos, for watched markers
+	hideWatched     bool              // Whether watched videos are hidden from the subscriptions feed
+	feedSeen        feedVisits        // Previous visit of the subscriptions feed by channel, for the new markers
+	feedShownAt     time.Time         // When the subscriptions feed was loaded, recorded as seen when leaving it
 }
 
 // Messages
@@ -115,10 +155,13 @@ var (
 		Padding(1)
 )
 
-func Menu() {
+// Menu runs the TUI. An incognito session starts with history recording off.
+func Menu(store credentials.Store, incognito bool) {
 	setupCleanupHandlers()
 	
-	p := tea.NewProgram(initialModel(), tea.WithAltScreen(), tea.WithMouseCellMotion())
+	m := initialModel(store)
+	m.incognito = incognito
+	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
 	if _, err := p.Run(); err != nil {
 		CleanupMpvProcesses()
 		os.Exit(1)
@@ -126,19 +169,57 @@ func Menu() {
 	CleanupMpvProcesses()
 }
 
-func initialModel() model {
-	// Initialize YouTube client
-	config := youtube.Config{
+func initialModel(store credentials.Store) model {
+	return model{
+		yt:             newYouTubeClient(store),
+		store:          store,
+		profile:        currentProfile(),
+		currentView:    MainMenuView,
+		items:          mainMenuItems(),
+		cursor:         0,
+		currentDetails: nil,
+		loading:        false,
+		width:          80,
+		height:         24,
+		viewport:       15,
+		viewportOffset: 0,
+		thumbnailCache: make(map[string]string),
+		segments:       make(segmentCache),
+		startTimes:     make(startTimeCache),
+		hideUpcoming:   viper.GetBool("channels.hide_upcoming"),
+		hideWatched:    viper.GetBool("channels.hide_watched"),
+		watched:        make(progressCache),
+		hideShorts:     viper.GetBool("shorts.hide"),
+		dearrow:        newDeArrowClient(),
+		branding:       make(brandingCache),
+		videoInfo:      make(videoInfoCache),
+		sortByDate:     true, // Default to sorting by date (newest first)
+	}
+}
+
+// newYouTubeClient builds the client for the active profile's configuration
+func newYouTubeClient(store credentials.Store) *youtube.YouTube {
+	logger := utils.SlogLogger()
+	ytConfig := youtube.Config{
 		InvidiousURL: viper.GetString("invidious.instance"),
 		ProxyURL:     viper.GetString("invidious.proxy"),
 		ClientID:     viper.GetString("youtube.clientid"),
-		ClientSecret: viper.GetString("youtube.secretid"),
-		RedirectURL:  "http://localhost:8080/oauth2callback",
+		TokenStore:   credentials.TokenStore{Store: store},
+		WriteAccess:  viper.GetBool("youtube.write"),
+		ShortsTab:    viper.GetBool("shorts.tab_lookup"),
+		Logger:       logger,
+		Middleware:   []youtube.Middleware{youtube.LoggingMiddleware(logger)},
+	}
+	// Only account mode needs the secret; reading it may unlock the encrypted store
+	if config.AccountEnabled() {
+		ytConfig.ClientSecret = clientSecret(store)
 	}
-	yt := youtube.New(config)
+	return youtube.New(ytConfig, youtube.WithTimeout(viper.GetDuration("invidious.timeout")))
+}
 
-	// Create main menu items
-	mainMenuItems := []interface{}{
+// mainMenuItems lists the entries of the main menu
+func mainMenuItems() []interface{} {
+	return []interface{}{
 		menuItem{
 			name:        "Search Videos",
 			id:          "search",
@@ -149,27 +230,59 @@ func initialModel() model {
 			id:          "subscribed",
 			description: "Browse videos from your subscribed channels",
 		},
+		menuItem{
+			name:        "Shorts",
+			id:          "shorts",
+			description: "Browse the Shorts of your subscribed channels",
+		},
+		menuItem{
+			name:        "Continue Watching",
+			id:          "continue",
+			description: "Resume the videos you stopped in the middle of",
+		},
+		menuItem{
+			name:        "Watch Later",
+			id:          "later",
+			description: "Play the videos you saved for later with b",
+		},
 		menuItem{
 			name:        "Watch History",
 			id:          "history",
 			description: "View your watch history",
 		},
+		menuItem{
+			name:        "Statistics",
+			id:          "stats",
+			description: "Watch time per day and week, top channels and streaks from your history",
+		},
+		menuItem{
+			name:        "My Playlists",
+			id:          "playlists",
+			description: "Browse the playlists of your YouTube account (requires login)",
+		},
+		menuItem{
+			name:        "Liked Videos",
+			id:          "liked",
+			description: "Browse the videos you liked on YouTube (requires login)",
+		},
+		menuItem{
+			name:        "Switch Profile",
+			id:          "profiles",
+			description: fmt.Sprintf("Current profile: %s\n\nSwitch to another profile with its own subscriptions, account, history and downloads.", config.ActiveProfile()),
+		},
 	}
+}
 
-	return model{
-		yt:             yt,
-		currentView:    MainMenuView,
-		items:          mainMenuItems,
-		cursor:         0,
-		currentDetails: nil,
-		loading:        false,
-		width:          80,
-		height:         24,
-		viewport:       15,
-		viewportOffset: 0,
-		thumbnailCache: make(map[string]string),
-		sortByDate:     true, // Default to sorting by date (newest first)
+// clientSecret prefers a secret still set in config and falls back to the credential store
+func clientSecret(store credentials.Store) string {
+	if secret := viper.GetString("youtube.secretid"); secret != "" && secret != config.PlaceholderSecret {
+		return secret
+	}
+	secret, err := store.Get(credentials.ClientSecretKey)
+	if err != nil && !errors.Is(err, credentials.ErrNotFound) {
+		utils.Logger.Error("Failed to read client secret from credential store.", zap.Error(err))
 	}
+	return secret
 }
 
 func (m model) Init() tea.Cmd {
@@ -182,8 +295,8 @@ func (m model) Init() tea.Cmd {
 // sortVideosByDate sorts videos by publication date (newest first if sortByDate is true)
 // For search results, always preserve relevance order regardless of sortByDate setting
 func (m *model) sortVideosByDate(videos []youtube.SearchResultItem) []youtube.SearchResultItem {
-	// Never sort search results - preserve relevance order
-	if m.currentView == SearchResultsView {
+	// Never sort search results or playlists - preserve relevance and playlist order
+	if m.currentView == SearchResultsView || m.currentView == PlaylistVideosView {
 		return videos
 	}
 	
@@ -196,10 +309,8 @@ func (m *model) sortVideosByDate(videos []youtube.SearchResultItem) []youtube.Se
 	sorted := make([]youtube.SearchResultItem, len(videos))
 	copy(sorted, videos)
 	
-	sort.Slice(sorted, func(i, j int) bool {
-		// Sort by Published timestamp (newest first)
-		return sorted[i].Published > sorted[j].Published
-	})
+	// Sort by Published timestamp (newest first), keeping live and upcoming videos apart
+	sortFeedByDate(sorted)
 	
 	return sorted
 }
@@ -214,11 +325,11 @@ func loadSearchResults(yt *youtube.YouTube, query string) tea.Cmd {
 	}
 }
 
-func loadSubscribedVideos(yt *youtube.YouTube) tea.Cmd {
+func loadSubscribedVideos(yt *youtube.YouTube, p profile) tea.Cmd {
 	return func() tea.Msg {
 		// Check if using local subscriptions
-		if viper.GetBool("channels.local") {
-			results, err := yt.Subscriptions().GetVideosFromChannels(viper.GetStringSlice("channels.subscribed"))
+		if p.settings.GetBool("channels.local") {
+			results, err := yt.Subscriptions().GetVideosFromChannels(p.settings.GetStringSlice("channels.subscribed"))
 			if err != nil {
 				return errMsg{err}
 			}
@@ -238,15 +349,33 @@ func loadSubscribedVideos(yt *youtube.YouTube) tea.Cmd {
 	}
 }
 
-func loadHistoryVideos() tea.Cmd {
+func loadShorts(yt *youtube.YouTube, p profile) tea.Cmd {
+	return func() tea.Msg {
+		if p.settings.GetBool("channels.local") {
+			results, err := yt.Subscriptions().GetShortsFromChannels(p.settings.GetStringSlice("channels.subscribed"))
+			if err != nil {
+				return errMsg{err}
+			}
+			return videosLoadedMsg{results}
+		}
+		if err := yt.Authenticate(); err != nil {
+			return errMsg{err}
+		}
+		results, err := yt.Subscriptions().GetAllShorts()
+		if err != nil {
+			return errMsg{err}
+		}
+		return videosLoadedMsg{results}
+	}
+}
+
+func loadHistoryVideos(p profile) tea.Cmd {
 	return func() tea.Msg {
-		configDir, err := config.GetConfigDirPath()
+		configDir, err := p.dir()
 		if err != nil {
 			return errMsg{err}
 		}
-		historyFilePath := filepath.Join(configDir, "watched_history.json")
-		
-		historyItems, err := history.Load(historyFilePath)
+		historyItems, err := history.Videos(configDir)
 		if err != nil {
 			return errMsg{err}
 		}
@@ -255,6 +384,18 @@ func loadHistoryVideos() tea.Cmd {
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	updated, cmd := m.update(msg)
+	next := updated.(model)
+	// Whatever changed the selection or the list, look up segments, titles and metadata for it
+	var branding tea.Cmd
+	if _, ok := msg.(brandingLoadedMsg); !ok {
+		// Failed lookups wait for the next change rather than hammering the server
+		branding = next.loadBranding()
+	}
+	return next, tea.Batch(cmd, next.loadSegmentsForDetails(), branding, next.loadVideoInfo())
+}
+
+func (m model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if m.err != nil {
 		switch msg := msg.(type) {
 		case tea.KeyMsg:
@@ -275,17 +416,8 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case videosLoadedMsg:
 		m.loading = false
 		// Sort videos by date if enabled
-		sortedVideos := m.sortVideosByDate(msg.items)
-		// Convert to interface{} slice
-		m.items = make([]interface{}, len(sortedVideos))
-		for i, item := range sortedVideos {
-			m.items[i] = item
-		}
-		m.videoItems = sortedVideos
-		m.cursor = 0
-		m.viewportOffset = 0
-		m.updateViewport()
-		m.updateCurrentDetails()
+		m.videoItems = m.sortVideosByDate(msg.items)
+		m.showVideos()
 		
 		// Limit cache size to prevent memory growth
 		if len(m.thumbnailCache) > 20 {
@@ -293,40 +425,279 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.thumbnailCache = make(map[string]string)
 		}
 		
+		if m.currentView == SubscribedView {
+			if m.feedShownAt.IsZero() {
+				m.feedShownAt = time.Now()
+			}
+			return m, visitFeed(msg.items, m.profile)
+		}
+		return m, nil
+
+	case feedVisitMsg:
+		m.feedSeen = msg.seen
+		return m, nil
+
+	case continueWatchingMsg:
+		if m.currentView != ContinueWatchingView {
+			return m, nil
+		}
+		m.loading = false
+		m.progress = make(progressCache, len(msg.entries))
+		m.videoItems = make([]youtube.SearchResultItem, 0, len(msg.entries))
+		for _, entry := range msg.entries {
+			m.progress[entry.Video.VideoID] = entry
+			m.videoItems = append(m.videoItems, entry.Video)
+		}
+		// Keep the last watched first whatever the sort setting
+		m.showVideos()
+		return m, nil
+
+	case watchLaterMsg:
+		if m.currentView != WatchLaterView {
+			return m, nil
+		}
+		m.loading = false
+		// Keep the queue's order whatever the sort setting
+		m.videoItems = msg.videos
+		m.showVideos()
+		return m, nil
+
+	case watchLaterToggledMsg:
+		m.status = watchLaterStatus(msg)
+		if m.currentView == WatchLaterView && !msg.added {
+			m.dropFromWatchLater(msg.video.VideoID)
+		}
 		return m, nil
 
 	case searchResultsMsg:
 		m.loading = false
 		m.currentView = SearchResultsView
 		// Sort videos by date if enabled
-		sortedVideos := m.sortVideosByDate(msg.items)
-		// Convert to interface{} slice
-		m.items = make([]interface{}, len(sortedVideos))
-		for i, item := range sortedVideos {
+		m.videoItems = m.sortVideosByDate(msg.items)
+		m.showVideos()
+		return m, nil
+
+	case profilesLoadedMsg:
+		m.loading = false
+		m.items = msg.items
+		m.videoItems = nil
+		m.cursor = 0
+		m.viewportOffset = 0
+		m.currentDetails = nil
+		m.updateViewport()
+		return m, nil
+
+	case profileSwitchedMsg:
+		m.store = msg.store
+		m.profile = currentProfile()
+		m.yt = newYouTubeClient(msg.store)
+		m.thumbnailCache = make(map[string]string)
+		m.dearrow = newDeArrowClient()
+		m.branding = make(brandingCache)
+		m.videoInfo = make(videoInfoCache)
+		return m.goBack()
+
+	case playlistsPageMsg:
+		if m.currentView != PlaylistsView {
+			// The view was left while the page loaded
+			return m, nil
+		}
+		m.loading = false
+		m.loadingMore = false
+		m.nextPageToken = msg.nextPageToken
+		if !msg.appendPage {
+			m.items = nil
+			m.cursor = 0
+			m.viewportOffset = 0
+		}
+		for _, playlist := range msg.playlists {
+			m.items = append(m.items, playlist)
+		}
+		m.videoItems = nil
+		m.updateViewport()
+		m.updateCurrentDetails()
+		return m, nil
+
+	case videoPageMsg:
+		if m.currentView != PlaylistVideosView {
+			return m, nil
+		}
+		if msg.title != "" {
+			m.playlistTitle = msg.title
+		}
+		m.loading = false
+		m.loadingMore = false
+		m.nextPageToken = msg.nextPageToken
+		if !msg.appendPage {
+			m.items = nil
+			m.videoItems = nil
+			m.cursor = 0
+			m.viewportOffset = 0
+		}
+		for _, item := range msg.items {
+			m.items = append(m.items, item)
+		}
+		m.videoItems = append(m.videoItems, msg.items...)
+		m.updateViewport()
+		m.updateCurrentDetails()
+		return m, nil
+
+	case linkLoadedMsg:
+		if m.currentView != LinkView {
+			return m, nil
+		}
+		m.loading = false
+		m.linkTitle = msg.title
+		m.items = make([]interface{}, len(msg.items))
+		for i, item := range msg.items {
 			m.items[i] = item
 		}
-		m.videoItems = sortedVideos
+		m.videoItems = msg.items
 		m.cursor = 0
 		m.viewportOffset = 0
 		m.updateViewport()
 		m.updateCurrentDetails()
 		return m, nil
 
+	case brandingLoadedMsg:
+		for videoID, branding := range msg.branding {
+			m.branding[videoID] = branding
+		}
+		// Forget the failed lookups so that they are retried when scrolled to again
+		for _, videoID := range msg.failed {
+			delete(m.branding, videoID)
+		}
+		return m, nil
+
+	case videoInfoLoadedMsg:
+		for videoID, video := range msg.videos {
+			m.videoInfo[videoID] = video
+		}
+		if m.currentDetails != nil {
+			details := m.withVideoInfo(*m.currentDetails)
+			m.currentDetails = &details
+		}
+		return m, nil
+
+	case segmentsLoadedMsg:
+		m.segments[msg.videoID] = msg.segments
+		return m, nil
+
 	case thumbnailLoadedMsg:
 		// Store thumbnail in cache
 		m.thumbnailCache[msg.cacheKey] = msg.thumbnail
 		return m, nil
 
+	case historyRemovedMsg:
+		if msg.removed.Len() == 0 {
+			return m, nil
+		}
+		m.historyBrowse.undo = append(m.historyBrowse.undo, msg.removed)
+		m.status = fmt.Sprintf("✓ Removed %d video(s) from history, z: undo", msg.removed.Len())
+		if m.currentView == HistoryView {
+			m.dropVideos(msg.removed)
+		}
+		return m, nil
+
+	case historyRestoredMsg:
+		m.status = fmt.Sprintf("✓ Restored %d video(s) to history", msg.restored.Len())
+		if m.currentView == HistoryView {
+			return m, loadHistoryVideos(m.profile)
+		}
+		return m, nil
+
+	case statsLoadedMsg:
+		if m.currentView != StatsView {
+			return m, nil
+		}
+		m.loading = false
+		m.stats = &msg.stats
+		return m, nil
+
+	case watchStateMsg:
+		m.watched = msg.entries
+		if m.currentView == SubscribedView && m.hideWatched {
+			m.reshowVideos()
+		}
+		return m, nil
+
+	case watchedMarkedMsg:
+		if msg.found {
+			m.watched[msg.videoID] = msg.entry
+		} else {
+			delete(m.watched, msg.videoID)
+		}
+		if msg.entry.Finished() && msg.found {
+			m.status = "✓ Marked watched"
+			if m.currentView == WatchLaterView && viper.GetBool("watch_later.remove_watched") {
+				m.dropFromWatchLater(msg.videoID)
+			}
+		} else {
+			m.status = "✓ Marked unwatched"
+		}
+		if m.currentView == SubscribedView && m.hideWatched {
+			m.reshowVideos()
+		}
+		return m, nil
+
 	case errMsg:
 		m.err = msg.err
 		m.loading = false
 		return m, nil
 
+	case statusMsg:
+		if msg.err != nil {
+			m.status = "✗ " + msg.err.Error()
+		} else {
+			m.status = "✓ " + msg.text
+		}
+		return m, nil
+
 	case tea.KeyMsg:
+		// A status only lives until the next key press
+		m.status = ""
+
+		if m.currentView == PlaylistInputView {
+			switch msg.String() {
+			case "enter":
+				m.currentView = m.returnView
+				if m.currentDetails != nil && m.playlistInput != "" {
+					return m, addToPlaylist(m.yt, *m.currentDetails, m.playlistInput, m.profile.settings)
+				}
+			case "backspace":
+				if len(m.playlistInput) > 0 {
+					m.playlistInput = m.playlistInput[:len(m.playlistInput)-1]
+				}
+			case "esc":
+				m.currentView = m.returnView
+			case "ctrl+c":
+				return m, tea.Quit
+			default:
+				if msg.Type == tea.KeyRunes {
+					m.playlistInput += string(msg.Runes)
+				}
+			}
+			return m, nil
+		}
+
+		if m.currentView == HistoryView {
+			if next, cmd, handled := m.handleHistoryKey(msg); handled {
+				return next, cmd
+			}
+		}
+		if m.currentView == WatchLaterView {
+			if next, cmd, handled := m.handleWatchLaterKey(msg); handled {
+				return next, cmd
+			}
+		}
+
 		// Handle search input first
 		if m.currentView == SearchInputView {
 			switch msg.String() {
 			case "enter":
+				if ref, err := youtube.ParseURL(m.searchQuery); err == nil {
+					return m.openLink(ref)
+				}
 				if m.searchQuery != "" {
 					m.loading = true
 					return m, loadSearchResults(m.yt, m.searchQuery)
@@ -336,29 +707,25 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.searchQuery = m.searchQuery[:len(m.searchQuery)-1]
 				} else {
 					m.currentView = MainMenuView
-					m.items = []interface{}{
-						menuItem{name: "Search Videos", id: "search", description: "Search for videos on YouTube/Invidious"},
-						menuItem{name: "Subscribed Channels", id: "subscribed", description: "Browse videos from your subscribed channels"},
-						menuItem{name: "Watch History", id: "history", description: "View your watch history"},
-					}
+					m.items = mainMenuItems()
 					m.cursor = 0
 					m.currentDetails = nil
 				}
-			case "escape":
+			case "esc":
 				m.currentView = MainMenuView
-				m.items = []interface{}{
-					menuItem{name: "Search Videos", id: "search", description: "Search for videos on YouTube/Invidious"},
-					menuItem{name: "Subscribed Channels", id: "subscribed", description: "Browse videos from your subscribed channels"},
-					menuItem{name: "Watch History", id: "history", description: "View your watch history"},
-				}
+				m.items = mainMenuItems()
 				m.cursor = 0
 				m.searchQuery = ""
 				m.currentDetails = nil
 			case "ctrl+c":
 				return m, tea.Quit
 			default:
-				if len(msg.String()) == 1 {
-					m.searchQuery += msg.String()
+				// Runes also carry bracketed pastes
+				switch msg.Type {
+				case tea.KeyRunes:
+					m.searchQuery += string(msg.Runes)
+				case tea.KeySpace:
+					m.searchQuery += " "
 				}
 			}
 			return m, nil
@@ -366,7 +733,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		switch msg.String() {
 		case "ctrl+c", "q":
-			return m, tea.Quit
+			return m, tea.Sequence(m.leaveFeed(), tea.Quit)
 		case "up", "k":
 			if m.cursor > 0 {
 				m.updateViewport() // Ensure viewport is current before navigation
@@ -381,6 +748,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.updateViewport()
 				m.updateCurrentDetails()
 			}
+			return m, m.loadMore()
 		case "g":
 			if len(m.items) > 0 {
 				m.cursor = 0
@@ -394,6 +762,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.updateViewportForBottom()
 				m.updateCurrentDetails()
 			}
+			return m, m.loadMore()
 		case "pageup", "left":
 			if len(m.items) > 0 {
 				// Recalculate viewport size to ensure it's current
@@ -420,6 +789,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.updateViewport()
 				m.updateCurrentDetails()
 			}
+			return m, m.loadMore()
 		case "enter":
 			if len(m.items) > 0 {
 				return m.selectItem()
@@ -427,16 +797,16 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "backspace", "h":
 			return m.goBack()
 		case "p", " ":
-			if m.currentDetails != nil && (m.currentView == SearchResultsView || m.currentView == SubscribedView || m.currentView == HistoryView) {
-				return m, playVideo(*m.currentDetails, false)
+			if m.currentDetails != nil && m.isVideoView() {
+				return m, playVideo(*m.currentDetails, false, m.startTimes[m.currentDetails.VideoID], m.incognito, m.profile)
 			}
 		case "d":
-			if m.currentDetails != nil && (m.currentView == SearchResultsView || m.currentView == SubscribedView || m.currentView == HistoryView) {
-				return m, downloadVideo(*m.currentDetails)
+			if m.currentDetails != nil && m.isVideoView() {
+				return m, downloadVideo(*m.currentDetails, m.profile)
 			}
 		case "t":
-			if m.currentDetails != nil && (m.currentView == SearchResultsView || m.currentView == SubscribedView || m.currentView == HistoryView) {
-				return m, openThumbnail(*m.currentDetails)
+			if m.currentDetails != nil && m.isVideoView() {
+				return m, openThumbnail(m.yt.Client().ThumbnailURL(m.currentDetails.VideoID, "maxresdefault"), m.currentDetails.VideoID, m.profile)
 			}
 		case "s":
 			// Toggle sort by date (only for subscriptions and history, not search results)
@@ -444,36 +814,151 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.sortByDate = !m.sortByDate
 				// Re-sort current items
 				if len(m.videoItems) > 0 {
-					sortedVideos := m.sortVideosByDate(m.videoItems)
-					m.items = make([]interface{}, len(sortedVideos))
-					for i, item := range sortedVideos {
-						m.items[i] = item
-					}
-					m.videoItems = sortedVideos
-					m.cursor = 0
-					m.viewportOffset = 0
-					m.updateViewport()
-					m.updateCurrentDetails()
+					m.videoItems = m.sortVideosByDate(m.videoItems)
+					m.showVideos()
 				}
 				return m, nil
 			}
+		case "S":
+			// Toggle Shorts in the subscriptions feed and search results
+			if m.currentView == SubscribedView || m.currentView == SearchResultsView {
+				m.hideShorts = !m.hideShorts
+				m.showVideos()
+				return m, nil
+			}
+		case "F":
+			// Cycle the period of the statistics
+			if m.currentView == StatsView {
+				m.statsRange = (m.statsRange + 1) % len(statsRanges)
+				m.loading = true
+				return m, loadStats(statsRanges[m.statsRange], m.profile)
+			}
+		case "w":
+			// Toggle watched videos in the subscriptions feed
+			if m.currentView == SubscribedView {
+				m.hideWatched = !m.hideWatched
+				m.showVideos()
+				return m, nil
+			}
+		case "b":
+			// Save the selected video for later, or drop it from Watch Later
+			if m.currentDetails != nil && m.isVideoView() {
+				return m, toggleWatchLater(*m.currentDetails, m.profile)
+			}
+		case "C":
+			// Catch up: every video of the subscriptions feed is seen
+			if m.currentView == SubscribedView {
+				if !m.recordsFeed() {
+					m.status = "✗ Feed visits are not recorded in incognito or with the history off"
+					return m, nil
+				}
+				return m, m.catchUpFeed(time.Now())
+			}
+		case "m":
+			// Mark the selected video watched or unwatched without playing it
+			if m.currentDetails != nil && m.isVideoView() {
+				if m.incognito || !viper.GetBool("history.enable") {
+					m.status = "✗ Marks are not recorded in incognito or with the history off"
+					return m, nil
+				}
+				return m, markWatched(*m.currentDetails, !m.isWatched(*m.currentDetails), m.profile)
+			}
+		case "u":
+			// Toggle upcoming premieres in the subscriptions feed
+			if m.currentView == SubscribedView {
+				m.hideUpcoming = !m.hideUpcoming
+				m.showVideos()
+				return m, nil
+			}
+		case "+", "-":
+			if m.currentDetails != nil && m.isVideoView() {
+				return m, setSubscription(m.yt, *m.currentDetails, msg.String() == "+", m.profile)
+			}
+		case "r", "R":
+			if m.currentDetails != nil && m.isVideoView() {
+				rating := youtube.RatingLike
+				if msg.String() == "R" {
+					rating = youtube.RatingDislike
+				}
+				return m, rateVideo(m.yt, *m.currentDetails, rating, m.profile.settings)
+			}
+		case "a":
+			if m.currentDetails != nil && m.isVideoView() {
+				m.returnView = m.currentView
+				m.currentView = PlaylistInputView
+				m.playlistInput = ""
+				return m, nil
+			}
+		case "o":
+			// Toggle between DeArrow and original titles
+			if m.dearrow != nil {
+				m.originalTitles = !m.originalTitles
+				return m, nil
+			}
+		case "I":
+			// Toggle incognito for the rest of the session
+			m.incognito = !m.incognito
+			if m.incognito {
+				m.status = "✓ Incognito: plays are not recorded"
+			} else {
+				m.status = "✓ Incognito off: plays are recorded again"
+			}
+			return m, nil
 		case "/":
 			// Allow search from any view
+			leave := m.leaveFeed()
 			m.currentView = SearchInputView
 			m.searchQuery = ""
-			return m, nil
+			return m, leave
 		}
 	}
 
 	return m, nil
 }
 
+// showVideos lists videoItems from the top, leaving out upcoming premieres of the
+// subscriptions feed and Shorts of the feed and search when they are hidden
+func (m *model) showVideos() {
+	videos := m.videoItems
+	if m.currentView == SubscribedView && m.hideUpcoming {
+		videos = withoutUpcoming(videos)
+	}
+	if (m.currentView == SubscribedView || m.currentView == SearchResultsView) && m.hideShorts {
+		videos = withoutShorts(videos)
+	}
+	if m.currentView == SubscribedView && m.hideWatched {
+		videos = m.withoutWatched(videos)
+	}
+	if m.currentView == HistoryView {
+		videos = m.historyBrowse.filtered(videos)
+	}
+	m.items = make([]interface{}, len(videos))
+	for i, item := range videos {
+		m.items[i] = item
+	}
+	m.cursor = 0
+	m.viewportOffset = 0
+	m.currentDetails = nil
+	m.updateViewport()
+	m.updateCurrentDetails()
+}
+
+// isVideoView reports whether the current view lists videos that can be acted upon
+func (m model) isVideoView() bool {
+	switch m.currentView {
+	case SearchResultsView, SubscribedView, HistoryView, PlaylistVideosView, LinkView, ShortsView, ContinueWatchingView, WatchLaterView:
+		return true
+	}
+	return false
+}
+
 func (m *model) updateCurrentDetails() {
 	if len(m.items) > 0 && m.cursor < len(m.items) {
 		switch item := m.items[m.cursor].(type) {
 		case youtube.SearchResultItem:
+			item = m.withVideoInfo(item)
 			m.currentDetails = &item
-		case menuItem:
+		case menuItem, youtube.Playlist:
 			m.currentDetails = nil
 		}
 	}
@@ -491,57 +976,139 @@ func (m model) selectItem() (model, tea.Cmd) {
 			return m, nil
 		case "subscribed":
 			m.currentView = SubscribedView
+			m.feedSeen = nil
+			m.feedShownAt = time.Time{}
+			m.loading = true
+			return m, tea.Batch(loadSubscribedVideos(m.yt, m.profile), loadWatchState(m.profile))
+		case "shorts":
+			m.currentView = ShortsView
+			m.loading = true
+			return m, loadShorts(m.yt, m.profile)
+		case "continue":
+			m.currentView = ContinueWatchingView
 			m.loading = true
-			return m, loadSubscribedVideos(m.yt)
+			return m, loadContinueWatching(m.profile)
+		case "later":
+			m.currentView = WatchLaterView
+			m.loading = true
+			return m, tea.Batch(loadWatchLater(m.profile), loadWatchState(m.profile))
 		case "history":
 			m.currentView = HistoryView
+			m.historyBrowse = historyBrowser{}
 			m.loading = true
-			return m, loadHistoryVideos()
+			return m, loadHistoryVideos(m.profile)
+		case "stats":
+			m.currentView = StatsView
+			m.items = nil
+			m.currentDetails = nil
+			m.stats = nil
+			m.loading = true
+			return m, loadStats(statsRanges[m.statsRange], m.profile)
+		case "profiles":
+			m.currentView = ProfilesView
+			m.loading = true
+			return m, loadProfiles()
+		case "playlists":
+			m.currentView = PlaylistsView
+			m.loading = true
+			m.nextPageToken = ""
+			return m, loadMyPlaylists(m.yt, "", false)
+		case "liked":
+			m.currentView = PlaylistVideosView
+			m.loading = true
+			m.playlistID = likedPlaylistID
+			m.publicPlaylist = false
+			m.playlistTitle = "Liked Videos"
+			m.nextPageToken = ""
+			m.savedPlaylists = nil
+			return m, loadPlaylistVideos(m.yt, likedPlaylistID, "", false)
+		}
+		if name, ok := profileFromItemID(v.id); ok {
+			if name == config.ActiveProfile() {
+				return m.goBack()
+			}
+			return m, switchProfile(name)
 		}
+	case youtube.Playlist:
+		m.savedPlaylists = &savedList{items: m.items, cursor: m.cursor, nextPageToken: m.nextPageToken}
+		m.currentView = PlaylistVideosView
+		m.loading = true
+		m.playlistID = v.ID
+		m.publicPlaylist = false
+		m.playlistTitle = v.Title
+		m.nextPageToken = ""
+		return m, loadPlaylistVideos(m.yt, v.ID, "", false)
 	case youtube.SearchResultItem:
-		return m, playVideo(v, true)
+		if !m.incognito && viper.GetBool("history.enable") {
+			// The play is recorded, so the video shows as watched from now on
+			entry := m.watched[v.VideoID]
+			entry.Video, entry.Unwatched = v, false
+			m.watched[v.VideoID] = entry
+		}
+		return m, playVideo(v, true, m.startTimes[v.VideoID], m.incognito, m.profile)
 	}
 	
 	return m, nil
 }
 
-func playVideo(video youtube.SearchResultItem, addToHistory bool) tea.Cmd {
+// playVideo plays video in mpv. Incognito plays are kept out of the local history and
+// the YouTube account's history, and are neither resumed nor tracked.
+func playVideo(video youtube.SearchResultItem, addToHistory bool, start time.Duration, incognito bool, p profile) tea.Cmd {
 	return func() tea.Msg {
+		if video.IsUpcoming && !video.LiveNow {
+			return statusMsg{err: fmt.Errorf("%s has not started yet (%s)", video.Title, videoBadge(video, time.Now()))}
+		}
+		
 		videoURL := "https://www.youtube.com/watch?v=" + video.VideoID
 		utils.Logger.Info("Playing selected video in MPV.", zap.String("video_url", videoURL))
 		
-		cmd := exec.Command("mpv", videoURL)
-		runningMpvProcesses = append(runningMpvProcesses, cmd)
+		resumed := start
+		if !incognito {
+			resumed = resumeStart(video, start, p)
+		}
+		options := player.Options{
+			Segments:      playbackSegments(video.VideoID, p),
+			Start:         resumed,
+			Live:          video.LiveNow,
+			LiveFromStart: p.settings.GetBool("player.live_from_start"),
+			Incognito:     incognito,
+		}
+		
+		// Add to history if enabled and requested, and follow the position to resume later
+		configDir, err := p.dir()
+		recorded := err == nil && !incognito
+		tracked := recorded && addToHistory && p.settings.GetBool("history.enable")
+		if tracked {
+			history.Record(video, historyRetention(p), configDir)
+			options.OnProgress = trackPosition(video, configDir)
+		}
 		
 		go func() {
-			player.RunMPV(videoURL)
-			
-			// Remove from tracking list when mpv exits
-			for i, p := range runningMpvProcesses {
-				if p == cmd {
-					runningMpvProcesses = append(runningMpvProcesses[:i], runningMpvProcesses[i+1:]...)
-					break
-				}
+			progress, err := player.RunMPV(videoURL, options)
+			if err != nil {
+				utils.Logger.Debug("Mpv exited with an error.", zap.Error(err))
 			}
-			
-			// Add to history if enabled and requested
-			if addToHistory && viper.GetBool("history.enable") {
-				configDir, err := config.GetConfigDirPath()
-				if err == nil {
-					historyFilePath := filepath.Join(configDir, "watched_history.json")
-					history.Add(video, historyFilePath)
-				}
+			if tracked && options.OnProgress != nil {
+				options.OnProgress(progress)
+			}
+			played := history.Entry{Video: video, Position: progress.Position, Length: progress.Duration}
+			if recorded && played.PlayedToEnd() {
+				// Done with it, off the Watch Later queue
+				removeWatchedLater(video.VideoID, p)
 			}
 		}()
 		
+		if resumed > start {
+			return statusMsg{text: "Resuming at " + format.Duration(resumed)}
+		}
 		return nil
 	}
 }
 
-func downloadVideo(video youtube.SearchResultItem) tea.Cmd {
+func downloadVideo(video youtube.SearchResultItem, p profile) tea.Cmd {
 	return func() tea.Msg {
 		videoURL := "https://www.youtube.com/watch?v=" + video.VideoID
-		downloadDir := viper.GetString("download_dir")
+		downloadDir := p.settings.GetString("download_dir")
 		utils.Logger.Info("Downloading selected video with yt-dlp.", zap.String("video_url", videoURL))
 		
 		go download.RunYTDLP(videoURL, downloadDir)
@@ -550,24 +1117,43 @@ func downloadVideo(video youtube.SearchResultItem) tea.Cmd {
 	}
 }
 
-// Global variable to track running mpv processes
-var runningMpvProcesses []*exec.Cmd
-
 func (m model) goBack() (model, tea.Cmd) {
 	switch m.currentView {
-	case SearchResultsView, SubscribedView, HistoryView, SearchInputView:
-		// Back to main menu
-		m.currentView = MainMenuView
-		m.items = []interface{}{
-			menuItem{name: "Search Videos", id: "search", description: "Search for videos on YouTube/Invidious"},
-			menuItem{name: "Subscribed Channels", id: "subscribed", description: "Browse videos from your subscribed channels"},
-			menuItem{name: "Watch History", id: "history", description: "View your watch history"},
+	case PlaylistVideosView:
+		if m.savedPlaylists != nil {
+			// Back to the playlists list, where it was left
+			m.currentView = PlaylistsView
+			m.items = m.savedPlaylists.items
+			m.cursor = m.savedPlaylists.cursor
+			m.nextPageToken = m.savedPlaylists.nextPageToken
+			m.savedPlaylists = nil
+			m.videoItems = nil
+			m.loadingMore = false
+			m.updateViewport()
+			m.updateCurrentDetails()
+			return m, nil
 		}
+		m.nextPageToken = ""
+		m.loadingMore = false
+		m.currentView = MainMenuView
+		m.items = mainMenuItems()
 		m.cursor = 0
 		m.viewportOffset = 0
 		m.currentDetails = nil
 		m.updateViewport()
 		return m, nil
+	case SearchResultsView, SubscribedView, HistoryView, SearchInputView, ProfilesView, PlaylistsView, LinkView, ShortsView, ContinueWatchingView, StatsView, WatchLaterView:
+		// Back to main menu
+		leave := m.leaveFeed()
+		m.nextPageToken = ""
+		m.loadingMore = false
+		m.currentView = MainMenuView
+		m.items = mainMenuItems()
+		m.cursor = 0
+		m.viewportOffset = 0
+		m.currentDetails = nil
+		m.updateViewport()
+		return m, leave
 	case MainMenuView:
 		return m, tea.Quit
 	}
@@ -658,6 +1244,9 @@ func (m model) renderItemList(width, height, viewport, viewportOffset int) strin
 	switch m.currentView {
 	case MainMenuView:
 		title = "YouTube TUI"
+		if profile := config.ActiveProfile(); profile != config.DefaultProfile {
+			title += " [" + profile + "]"
+		}
 	case SearchInputView:
 		title = fmt.Sprintf("Search: %s", m.searchQuery)
 		if len(title) > width-4 {
@@ -665,21 +1254,63 @@ func (m model) renderItemList(width, height, viewport, viewportOffset int) strin
 		}
 	case SearchResultsView:
 		title = "Search Results (by relevance)"
+		if m.hideShorts {
+			title += " (Shorts hidden)"
+		}
+	case ShortsView:
+		title = "Shorts"
 	case SubscribedView:
 		title = "Subscribed Channels"
 		if m.sortByDate {
 			title += " (sorted by date)"
 		}
+		if m.hideUpcoming {
+			title += " (upcoming hidden)"
+		}
+		if m.hideWatched {
+			title += " (watched hidden)"
+		}
+		if count := m.newCount(""); count > 0 {
+			title += fmt.Sprintf(" (%d new)", count)
+		}
+		if m.hideShorts {
+			title += " (Shorts hidden)"
+		}
 	case HistoryView:
 		title = "Watch History"
 		if m.sortByDate {
 			title += " (sorted by date)"
 		}
+		title += m.historyBrowse.title()
+	case ContinueWatchingView:
+		title = "Continue Watching"
+	case WatchLaterView:
+		title = fmt.Sprintf("Watch Later (%d)", len(m.videoItems))
+	case StatsView:
+		title = fmt.Sprintf("Statistics (last %d days)", statsRanges[m.statsRange])
+	case ProfilesView:
+		title = "Profiles"
+	case PlaylistsView:
+		title = "My Playlists"
+	case PlaylistVideosView:
+		title = m.playlistTitle
+	case LinkView:
+		title = m.linkTitle
+	case PlaylistInputView:
+		title = fmt.Sprintf("Add to playlist (ID or URL): %s", m.playlistInput)
+		if width > 10 && len(title) > width-4 {
+			title = "..." + title[len(title)-(width-7):]
+		}
 	}
 	
 	content.WriteString(titleStyle.Width(width-4).Render(title))
 	content.WriteString("\n")
 
+	if m.currentView == StatsView && m.stats != nil {
+		content.WriteString(infoStyle.Render(clipLines(statsSummary(*m.stats, width-6), height-2)))
+		return content.String()
+	}
+
 	if len(m.items) == 0 {
 		content.WriteString(dimStyle.Render("No items found"))
 		return content.String()
@@ -706,11 +1337,28 @@ func (m model) renderItemList(width, height, viewport, viewportOffset int) strin
 		case menuItem:
 			itemText = item.name
 		case youtube.SearchResultItem:
-			itemText = item.Title
+			itemText = m.displayTitle(item)
+			if badge := videoBadge(item, time.Now()); badge != "" {
+				itemText = "[" + badge + "] " + itemText
+			}
+			if entry, ok := m.progress[item.VideoID]; ok && m.currentView == ContinueWatchingView {
+				itemText = progressBar(entry.Fraction()) + " " + itemText
+			}
+			if m.currentView == SubscribedView && m.isNew(item) {
+				itemText = "[NEW] " + itemText
+			}
+			if marker := m.watchMarker(item); marker != "" && m.showsWatchMarkers() {
+				itemText = marker + " " + itemText
+			}
+			if m.currentView == HistoryView && m.historyBrowse.selected[item.VideoID] {
+				itemText = "● " + itemText
+			}
 			if len(itemText) > width-10 {
 				itemText = itemText[:width-13] + "..."
 			}
 			itemText += " - " + item.Author
+		case youtube.Playlist:
+			itemText = fmt.Sprintf("%s (%d videos)", item.Title, item.ItemCount)
 		}
 		
 		// Truncate if too long
@@ -739,21 +1387,30 @@ func (m model) renderItemList(width, height, viewport, viewportOffset int) strin
 	}
 	if end < len(m.items) {
 		content.WriteString("\n" + dimStyle.Render("  ↓ more items below"))
+	} else if m.loadingMore {
+		content.WriteString("\n" + dimStyle.Render("  loading more..."))
 	}
 	
 	return content.String()
 }
 
 func (m model) renderDetails(width, height int) string {
+	if m.currentView == StatsView && m.stats != nil {
+		charts := titleStyle.Width(width-4).Render("Watch Time") + "\n"
+		return charts + infoStyle.Render(clipLines(statsCharts(*m.stats, width-6), height-3))
+	}
 	if m.currentDetails == nil {
 		// Show menu item descriptions or general info
 		if len(m.items) > 0 && m.cursor < len(m.items) {
-			if item, ok := m.items[m.cursor].(menuItem); ok {
+			switch item := m.items[m.cursor].(type) {
+			case menuItem:
 				return infoStyle.Render(item.description)
+			case youtube.Playlist:
+				return infoStyle.Render(fmt.Sprintf("%s\n\n%d videos\n\n%s", item.Title, item.ItemCount, item.Description))
 			}
 		}
 		if m.currentView == SearchInputView {
-			return infoStyle.Render("Type your search query and press Enter")
+			return infoStyle.Render("Type your search query and press Enter\n\nPaste a video, playlist or channel URL to open it directly")
 		}
 		return dimStyle.Render("Select an item to view details")
 	}
@@ -773,8 +1430,8 @@ func (m model) renderDetails(width, height int) string {
 
 	// Render thumbnail if available and there's space (need at least 12 lines total)
 	if maxLines > 12 {
-		// For YouTube videos, construct thumbnail URL
-		imageURL := fmt.Sprintf("https://img.youtube.com/vi/%s/maxresdefault.jpg", m.currentDetails.VideoID)
+		// Thumbnail from the client's configured thumbnail server
+		imageURL := m.yt.Client().ThumbnailURL(m.currentDetails.VideoID, "maxresdefault")
 		if imageURL != "" {
 			// Calculate dimensions - make it slightly bigger
 			thumbWidth := width - 2
@@ -837,7 +1494,7 @@ func (m model) renderDetails(width, height int) string {
 	}
 	
 	// Video title
-	title := m.currentDetails.Title
+	title := m.displayTitle(*m.currentDetails)
 	if len(title) > width-8 {
 		title = title[:width-11] + "..."
 	}
@@ -848,6 +1505,19 @@ func (m model) renderDetails(width, height int) string {
 		return details.String()
 	}
 	
+	// Original title when DeArrow replaced it
+	if title := m.currentDetails.Title; title != m.displayTitle(*m.currentDetails) {
+		if len(title) > width-17 {
+			title = title[:width-20] + "..."
+		}
+		details.WriteString(dimStyle.Render(fmt.Sprintf("Original title: %s", title)))
+		details.WriteString("\n")
+		linesUsed++
+		if linesUsed >= maxLines {
+			return details.String()
+		}
+	}
+	
 	// Author
 	details.WriteString(infoStyle.Render(fmt.Sprintf("Author: %s", m.currentDetails.Author)))
 	details.WriteString("\n")
@@ -856,9 +1526,19 @@ func (m model) renderDetails(width, height int) string {
 		return details.String()
 	}
 	
-	// Duration
-	duration := time.Duration(m.currentDetails.LengthSeconds) * time.Second
-	details.WriteString(infoStyle.Render(fmt.Sprintf("Duration: %s", duration.String())))
+	// Duration, or the live state which has none yet
+	switch {
+	case m.currentDetails.LiveNow:
+		details.WriteString(infoStyle.Render("Duration: ● LIVE"))
+	case m.currentDetails.IsUpcoming:
+		premiere := "Premieres: soon"
+		if start, ok := m.currentDetails.Premiere(); ok {
+			premiere = fmt.Sprintf("Premieres: %s (%s)", start.Format("2006-01-02 15:04"), videoBadge(*m.currentDetails, time.Now()))
+		}
+		details.WriteString(infoStyle.Render(premiere))
+	default:
+		details.WriteString(infoStyle.Render(fmt.Sprintf("Duration: %s", format.Duration(m.currentDetails.Length()))))
+	}
 	details.WriteString("\n")
 	linesUsed++
 	if linesUsed >= maxLines {
@@ -866,8 +1546,8 @@ func (m model) renderDetails(width, height int) string {
 	}
 	
 	// Views
-	if m.currentDetails.ViewCountText != "" {
-		details.WriteString(infoStyle.Render(fmt.Sprintf("Views: %s", m.currentDetails.ViewCountText)))
+	if views := viewsLabel(*m.currentDetails); views != "" {
+		details.WriteString(infoStyle.Render(fmt.Sprintf("Views: %s", views)))
 		details.WriteString("\n")
 		linesUsed++
 		if linesUsed >= maxLines {
@@ -876,8 +1556,59 @@ func (m model) renderDetails(width, height int) string {
 	}
 	
 	// Published
-	if m.currentDetails.PublishedText != "" {
-		details.WriteString(infoStyle.Render(fmt.Sprintf("Published: %s", m.currentDetails.PublishedText)))
+	if published := publishedLabel(*m.currentDetails, time.Now()); published != "" {
+		details.WriteString(infoStyle.Render(fmt.Sprintf("Published: %s", published)))
+		details.WriteString("\n")
+		linesUsed++
+		if linesUsed >= maxLines {
+			return details.String()
+		}
+	}
+	
+	// New videos of the channel since its previous visit, in the subscriptions feed
+	if label := m.newLabel(m.currentDetails.AuthorID, time.Now()); label != "" && m.currentView == SubscribedView {
+		details.WriteString(infoStyle.Render(fmt.Sprintf("New from channel: %s", label)))
+		details.WriteString("\n")
+		linesUsed++
+		if linesUsed >= maxLines {
+			return details.String()
+		}
+	}
+	
+	// Watched, for history entries
+	if viewed := m.currentDetails.ViewedAt(); !viewed.IsZero() {
+		details.WriteString(infoStyle.Render(fmt.Sprintf("Watched: %s", format.Relative(viewed, time.Now()))))
+		details.WriteString("\n")
+		linesUsed++
+		if linesUsed >= maxLines {
+			return details.String()
+		}
+	}
+	
+	// Where playback stopped, for partially watched videos
+	if entry, ok := m.progress[m.currentDetails.VideoID]; ok && m.currentView == ContinueWatchingView {
+		details.WriteString(infoStyle.Render(fmt.Sprintf("Progress: %s", progressLabel(entry))))
+		details.WriteString("\n")
+		linesUsed++
+		if linesUsed >= maxLines {
+			return details.String()
+		}
+	}
+	
+	// DeArrow thumbnail
+	if branding := m.branding[m.currentDetails.VideoID]; m.dearrow != nil && branding.HasThumbnail {
+		frame := time.Duration(branding.ThumbnailTime * float64(time.Second)).Round(time.Second)
+		details.WriteString(infoStyle.Render(fmt.Sprintf("DeArrow thumbnail: frame at %s", frame)))
+		details.WriteString("\n")
+		linesUsed++
+		if linesUsed >= maxLines {
+			return details.String()
+		}
+	}
+	
+	// SponsorBlock
+	if segments := m.segments[m.currentDetails.VideoID]; segments != nil {
+		details.WriteString(infoStyle.Render(fmt.Sprintf("SponsorBlock: %d segments", len(segments))))
 		details.WriteString("\n")
 		linesUsed++
 		if linesUsed >= maxLines {
@@ -940,12 +1671,7 @@ func (m model) renderDetails(width, height int) string {
 
 // CleanupMpvProcesses kills any running mpv processes when ytui exits
 func CleanupMpvProcesses() {
-	for _, cmd := range runningMpvProcesses {
-		if cmd.Process != nil {
-			cmd.Process.Kill()
-		}
-	}
-	runningMpvProcesses = nil
+	player.KillAll()
 }
 
 // setupCleanupHandlers sets up signal handlers to cleanup mpv processes on exit
@@ -1108,12 +1834,9 @@ func renderThumbnailInlineOptimized(imageURL string, width, height int, itemID s
 	return rendered, nil
 }
 
-func openThumbnail(video youtube.SearchResultItem) tea.Cmd {
+func openThumbnail(imageURL, videoID string, p profile) tea.Cmd {
 	return func() tea.Msg {
-		// For YouTube videos, construct thumbnail URL
-		imageURL := fmt.Sprintf("https://img.youtube.com/vi/%s/maxresdefault.jpg", video.VideoID)
-		
-		thumbnailPath := fmt.Sprintf("/tmp/ytui_thumb_%s.jpg", video.VideoID)
+		thumbnailPath := fmt.Sprintf("/tmp/ytui_thumb_%s.jpg", videoID)
 		
 		// Download thumbnail if it doesn't exist
 		if _, err := os.Stat(thumbnailPath); os.IsNotExist(err) {
@@ -1136,7 +1859,7 @@ func openThumbnail(video youtube.SearchResultItem) tea.Cmd {
 		}
 
 		// Get configured image viewer or use default
-		imageViewer := viper.GetString("image_viewer")
+		imageViewer := p.settings.GetString("image_viewer")
 		if imageViewer == "" {
 			imageViewer = "xdg-open" // Default for Linux
 		}
@@ -1158,16 +1881,38 @@ var helpText = strings.Join([]string{
 	"h/Bksp: back",
 	"t: thumbnail",
 	"s: sort by date",
+	"u: hide upcoming",
+	"w: hide watched",
+	"m: mark (un)watched",
+	"C: catch up",
+	"b: watch later",
+	"K/J: reorder watch later",
+	"S: hide Shorts",
 	"p/Space: play",
 	"d: download",
+	"+/-: (un)subscribe",
+	"r/R: like/dislike",
+	"a: add to playlist",
+	"o: original titles",
+	"f/c: filter history",
+	"F: history/stats period",
+	"v/x/z/X: select/remove/undo/clear history",
+	"I: incognito",
 	"/: search",
 	"q: quit",
 }, " • ")
 
 func (m model) renderHelp() string {
-	if len(helpText) > m.width-2 {
-		return dimStyle.Render(lipgloss.NewStyle().Width(m.width-2).Render(helpText))
+	text := helpText
+	if m.status != "" {
+		text = m.status + " │ " + text
+	}
+	if m.incognito {
+		text = "INCOGNITO │ " + text
+	}
+	if len(text) > m.width-2 {
+		return dimStyle.Render(lipgloss.NewStyle().Width(m.width-2).Render(text))
 	}
 	
-	return dimStyle.Render(helpText)
+	return dimStyle.Render(text)
 }
\ No newline at end of file
@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"errors"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Banh-Canh/ytui/internal/config"
+	"github.com/Banh-Canh/ytui/pkg/youtube"
+)
+
+// likedPlaylistID marks PlaylistVideosView showing liked videos rather than a playlist
+const likedPlaylistID = "liked"
+
+type playlistsPageMsg struct {
+	playlists     []youtube.Playlist
+	nextPageToken string
+	appendPage    bool
+}
+
+type videoPageMsg struct {
+	title         string // Playlist title, when the page tells it
+	items         []youtube.SearchResultItem
+	nextPageToken string
+	appendPage    bool
+}
+
+var errAccountDisabled = errors.New("playlists need a YouTube account, set channels.local: false or youtube.write: true in config")
+
+// savedList remembers the playlists view while one of its playlists is open
+type savedList struct {
+	items         []interface{}
+	cursor        int
+	nextPageToken string
+}
+
+func loadMyPlaylists(yt *youtube.YouTube, pageToken string, appendPage bool) tea.Cmd {
+	return func() tea.Msg {
+		if !appendPage {
+			if !config.AccountEnabled() {
+				return errMsg{errAccountDisabled}
+			}
+			if err := yt.Authenticate(); err != nil {
+				return errMsg{err}
+			}
+		}
+		page, err := yt.GetMyPlaylists(pageToken)
+		if err != nil {
+			return errMsg{err}
+		}
+		return playlistsPageMsg{playlists: page.Items, nextPageToken: page.NextPageToken, appendPage: appendPage}
+	}
+}
+
+func loadPlaylistVideos(yt *youtube.YouTube, playlistID, pageToken string, appendPage bool) tea.Cmd {
+	return func() tea.Msg {
+		if !appendPage {
+			if !config.AccountEnabled() {
+				return errMsg{errAccountDisabled}
+			}
+			if err := yt.Authenticate(); err != nil {
+				return errMsg{err}
+			}
+		}
+
+		var page youtube.VideoPage
+		var err error
+		if playlistID == likedPlaylistID {
+			page, err = yt.GetLikedVideos(pageToken)
+		} else {
+			page, err = yt.Playlists().Items(playlistID, pageToken)
+		}
+		if err != nil {
+			return errMsg{err}
+		}
+		return videoPageMsg{items: page.Items, nextPageToken: page.NextPageToken, appendPage: appendPage}
+	}
+}
+
+// loadMore fetches the next page once the cursor reaches the end of a paged list
+func (m *model) loadMore() tea.Cmd {
+	if m.nextPageToken == "" || m.loadingMore || len(m.items) == 0 || m.cursor < len(m.items)-1 {
+		return nil
+	}
+
+	switch m.currentView {
+	case PlaylistsView:
+		m.loadingMore = true
+		return loadMyPlaylists(m.yt, m.nextPageToken, true)
+	case PlaylistVideosView:
+		m.loadingMore = true
+		if m.publicPlaylist {
+			return loadPublicPlaylist(m.yt, m.playlistID, m.nextPageToken, true)
+		}
+		return loadPlaylistVideos(m.yt, m.playlistID, m.nextPageToken, true)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+
+	"github.com/Banh-Canh/ytui/internal/config"
+	"github.com/Banh-Canh/ytui/internal/credentials"
+	"github.com/Banh-Canh/ytui/internal/utils"
+)
+
+const profileItemPrefix = "profile:"
+
+// profile is the configuration directory and the settings of a profile. Commands take
+// the active one when they are created, so one finishing after a profile switch still
+// reads and writes the profile it was started in.
+type profile struct {
+	configDir string
+	dirErr    error
+	settings  *viper.Viper
+}
+
+// currentProfile returns the active profile. config.Load replaces the global viper
+// instance, so the settings taken here stay those of this profile.
+func currentProfile() profile {
+	configDir, err := config.GetConfigDirPath()
+	return profile{configDir: configDir, dirErr: err, settings: viper.GetViper()}
+}
+
+// dir returns the configuration directory of the profile
+func (p profile) dir() (string, error) {
+	return p.configDir, p.dirErr
+}
+
+type profilesLoadedMsg struct {
+	items []interface{}
+}
+
+type profileSwitchedMsg struct {
+	store credentials.Store
+}
+
+func loadProfiles() tea.Cmd {
+	return func() tea.Msg {
+		profiles, err := config.ListProfiles()
+		if err != nil {
+			return errMsg{err}
+		}
+
+		items := make([]interface{}, 0, len(profiles))
+		for _, name := range profiles {
+			item := menuItem{
+				name:        name,
+				id:          profileItemPrefix + name,
+				description: fmt.Sprintf("Switch to profile %q.", name),
+			}
+			if name == config.ActiveProfile() {
+				item.name += " (current)"
+				item.description = fmt.Sprintf("Profile %q is in use.", name)
+			}
+			items = append(items, item)
+		}
+		return profilesLoadedMsg{items}
+	}
+}
+
+// switchProfile reloads configuration and credentials for another profile. It runs
+// through tea.Exec so that a credentials passphrase prompt gets the real terminal.
+func switchProfile(name string) tea.Cmd {
+	switcher := &profileSwitch{name: name}
+	return tea.Exec(switcher, func(err error) tea.Msg {
+		if err != nil {
+			return errMsg{err}
+		}
+		return profileSwitchedMsg{store: switcher.store}
+	})
+}
+
+// profileSwitch implements tea.ExecCommand
+type profileSwitch struct {
+	name  string
+	store credentials.Store
+}
+
+func (p *profileSwitch) Run() error {
+	previous := config.ActiveProfile()
+	if err := p.activate(p.name); err != nil {
+		utils.Logger.Error("Failed to switch profile, restoring previous one.", zap.String("profile", p.name), zap.Error(err))
+		if restoreErr := p.activate(previous); restoreErr != nil {
+			return fmt.Errorf("failed to switch to profile %q: %v (restoring %q also failed: %v)", p.name, err, previous, restoreErr)
+		}
+		return fmt.Errorf("failed to switch to profile %q: %w", p.name, err)
+	}
+	utils.Logger.Info("Switched profile.", zap.String("profile", p.name))
+	return nil
+}
+
+func (p *profileSwitch) activate(name string) error {
+	if err := config.SetProfile(name); err != nil {
+		return err
+	}
+	if err := config.Load(); err != nil {
+		return err
+	}
+	store, err := credentials.OpenConfigured(credentials.PromptPassphrase)
+	if err != nil {
+		return err
+	}
+	if config.AccountEnabled() {
+		if err := credentials.Unlock(store); err != nil {
+			return err
+		}
+	}
+	p.store = store
+	return nil
+}
+
+func (p *profileSwitch) SetStdin(io.Reader)  {}
+func (p *profileSwitch) SetStdout(io.Writer) {}
+func (p *profileSwitch) SetStderr(io.Writer) {}
+
+// profileFromItemID extracts the profile name of a profiles view entry
+func profileFromItemID(id string) (string, bool) {
+	return strings.CutPrefix(id, profileItemPrefix)
+}
@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Banh-Canh/ytui/internal/format"
+	"github.com/Banh-Canh/ytui/internal/history"
+	"github.com/Banh-Canh/ytui/internal/player"
+	"github.com/Banh-Canh/ytui/pkg/youtube"
+)
+
+// progressCache holds the history entries of the videos in ContinueWatchingView by ID
+type progressCache map[string]history.Entry
+
+// progressBarWidth is the number of cells of the list's progress bars
+const progressBarWidth = 10
+
+type continueWatchingMsg struct {
+	entries []history.Entry
+}
+
+func loadContinueWatching(p profile) tea.Cmd {
+	return func() tea.Msg {
+		configDir, err := p.dir()
+		if err != nil {
+			return errMsg{err}
+		}
+		entries, err := history.ContinueWatching(configDir)
+		if err != nil {
+			return errMsg{err}
+		}
+		return continueWatchingMsg{entries}
+	}
+}
+
+// progressBar renders the played fraction of a video, such as ▰▰▰▱▱▱▱▱▱▱ 30%
+func progressBar(fraction float64) string {
+	filled := int(fraction*progressBarWidth + 0.5)
+	if filled > progressBarWidth {
+		filled = progressBarWidth
+	}
+	return fmt.Sprintf("%s%s %d%%", strings.Repeat("▰", filled), strings.Repeat("▱", progressBarWidth-filled), int(fraction*100))
+}
+
+// progressLabel renders the position of a partially watched video, such as 12:34 / 45:00 (27%)
+func progressLabel(entry history.Entry) string {
+	length := entry.Length
+	if length <= 0 {
+		length = entry.Video.Length()
+	}
+	return fmt.Sprintf("%s / %s (%d%%)", format.Duration(entry.Position), format.Duration(length), int(entry.Fraction()*100))
+}
+
+// resumeStart returns where to start a video: the explicit start if any, otherwise where
+// it was left off in the history
+func resumeStart(video youtube.SearchResultItem, start time.Duration, p profile) time.Duration {
+	if start > 0 || video.LiveNow || !p.settings.GetBool("history.enable") {
+		return start
+	}
+	configDir, err := p.dir()
+	if err != nil {
+		return start
+	}
+	if position, ok := history.Resume(video.VideoID, configDir); ok {
+		return position
+	}
+	return start
+}
+
+// historyRetention returns the retention configured for the watch history
+func historyRetention(p profile) history.Retention {
+	return history.Retention{
+		Days:       p.settings.GetInt("history.retention_days"),
+		MaxEntries: p.settings.GetInt("history.max_entries"),
+	}
+}
+
+// trackPosition returns the OnProgress callback saving the playback position in the
+// history, nil when it should not be tracked
+func trackPosition(video youtube.SearchResultItem, configDir string) func(player.Progress) {
+	if video.LiveNow {
+		return nil
+	}
+	return func(progress player.Progress) {
+		if progress.Position > 0 {
+			history.SavePosition(video.VideoID, progress.Position, progress.Duration, configDir)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+
+	"github.com/Banh-Canh/ytui/internal/player"
+	"github.com/Banh-Canh/ytui/internal/sponsorblock"
+	"github.com/Banh-Canh/ytui/internal/utils"
+)
+
+// segmentCache holds SponsorBlock segments by video ID. A nil entry marks a lookup that
+// is in flight or failed.
+type segmentCache map[string][]sponsorblock.Segment
+
+type segmentsLoadedMsg struct {
+	videoID  string
+	segments []sponsorblock.Segment
+}
+
+func sponsorBlockEnabled(settings *viper.Viper) bool {
+	return settings.GetBool("sponsorblock.enable")
+}
+
+// segmentActions returns the configured action per category, the defaults when none are set
+func segmentActions(settings *viper.Viper) map[string]sponsorblock.Action {
+	configured := settings.GetStringMapString("sponsorblock.categories")
+	if len(configured) == 0 {
+		configured = sponsorblock.DefaultActions()
+	}
+	actions := make(map[string]sponsorblock.Action, len(configured))
+	for category, action := range configured {
+		actions[category] = sponsorblock.Action(action)
+	}
+	return actions
+}
+
+// fetchSegments looks up the segments of the categories that are not ignored
+func fetchSegments(videoID string, settings *viper.Viper) ([]sponsorblock.Segment, error) {
+	var categories []string
+	for category, action := range segmentActions(settings) {
+		if action == sponsorblock.ActionSkip || action == sponsorblock.ActionChapter {
+			categories = append(categories, category)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return sponsorblock.NewClient(settings.GetString("sponsorblock.server")).Segments(ctx, videoID, categories)
+}
+
+// playbackSegments returns the segments mpv should handle for a video. Failures are
+// logged and the video plays without segments.
+func playbackSegments(videoID string, p profile) []player.Segment {
+	if !sponsorBlockEnabled(p.settings) {
+		return nil
+	}
+	segments, err := fetchSegments(videoID, p.settings)
+	if err != nil {
+		utils.Logger.Error("Failed to fetch SponsorBlock segments.", zap.String("videoID", videoID), zap.Error(err))
+		return nil
+	}
+
+	actions := segmentActions(p.settings)
+	playback := make([]player.Segment, 0, len(segments))
+	for _, s := range segments {
+		playback = append(playback, player.Segment{
+			Start:    s.Start,
+			End:      s.End,
+			Category: s.Category,
+			Skip:     actions[s.Category] == sponsorblock.ActionSkip,
+		})
+	}
+	return playback
+}
+
+// loadSegmentsForDetails fetches the segments of the selected video for the detail pane
+func (m *model) loadSegmentsForDetails() tea.Cmd {
+	if !sponsorBlockEnabled(viper.GetViper()) || m.currentDetails == nil {
+		return nil
+	}
+	videoID, settings := m.currentDetails.VideoID, m.profile.settings
+	if _, ok := m.segments[videoID]; ok {
+		return nil
+	}
+	// Mark the lookup as started so moving the cursor does not repeat it
+	m.segments[videoID] = nil
+
+	return func() tea.Msg {
+		segments, err := fetchSegments(videoID, settings)
+		if err != nil {
+			utils.Logger.Debug("Failed to fetch SponsorBlock segments.", zap.String("videoID", videoID), zap.Error(err))
+			return nil
+		}
+		if segments == nil {
+			segments = []sponsorblock.Segment{}
+		}
+		return segmentsLoadedMsg{videoID: videoID, segments: segments}
+	}
+}
@@ -0,0 +1,152 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Banh-Canh/ytui/internal/format"
+	"github.com/Banh-Canh/ytui/internal/history"
+)
+
+// statsRanges are the periods of StatsView in days, cycled with F
+var statsRanges = []int{7, 30, 90, 365}
+
+const (
+	// reportDays and reportWeeks bound the charts, the JSON export has every period
+	reportDays  = 14
+	reportWeeks = 8
+	// minBarWidth keeps bars readable in narrow terminals
+	minBarWidth = 5
+)
+
+type statsLoadedMsg struct {
+	stats history.Stats
+}
+
+func loadStats(days int, p profile) tea.Cmd {
+	return func() tea.Msg {
+		configDir, err := p.dir()
+		if err != nil {
+			return errMsg{err}
+		}
+		stats, err := history.StatsSince(time.Now().AddDate(0, 0, -(days-1)), configDir)
+		if err != nil {
+			return errMsg{err}
+		}
+		return statsLoadedMsg{stats}
+	}
+}
+
+// StatsReport renders the statistics as text with bar charts fitting width
+func StatsReport(stats history.Stats, width int) string {
+	return statsSummary(stats, width) + "\n\n" + statsCharts(stats, width)
+}
+
+// statsSummary renders the totals, the streaks and the top channels
+func statsSummary(stats history.Stats, width int) string {
+	var report strings.Builder
+	fmt.Fprintf(&report, "Since %s\n", format.Date(stats.From))
+	fmt.Fprintf(&report, "Plays: %d · Videos: %d · Watched: %s\n", stats.Plays, stats.Videos, watchTime(stats.WatchSeconds))
+	if stats.AverageLength > 0 {
+		fmt.Fprintf(&report, "Average video length: %s\n", format.Duration(time.Duration(stats.AverageLength)*time.Second))
+	}
+	fmt.Fprintf(&report, "Streak: %s · Longest: %s", days(stats.CurrentStreak), days(stats.LongestStreak))
+
+	if len(stats.TopChannels) > 0 {
+		report.WriteString("\n\nTop channels")
+		labels := make([]string, len(stats.TopChannels))
+		values := make([]float64, len(stats.TopChannels))
+		texts := make([]string, len(stats.TopChannels))
+		for i, channel := range stats.TopChannels {
+			labels[i] = channel.Name
+			values[i] = float64(channel.Plays)
+			texts[i] = fmt.Sprintf("%d · %s", channel.Plays, watchTime(channel.WatchSeconds))
+		}
+		report.WriteString("\n" + barChart(labels, values, texts, width))
+	}
+	return report.String()
+}
+
+// statsCharts renders the watch time of the last days and weeks
+func statsCharts(stats history.Stats, width int) string {
+	var report strings.Builder
+	report.WriteString("Per day")
+	chartDays := stats.Days
+	if len(chartDays) > reportDays {
+		chartDays = chartDays[len(chartDays)-reportDays:]
+	}
+	report.WriteString("\n" + periodChart(chartDays, "Mon 01-02", width))
+
+	report.WriteString("\n\nPer week")
+	chartWeeks := stats.Weeks
+	if len(chartWeeks) > reportWeeks {
+		chartWeeks = chartWeeks[len(chartWeeks)-reportWeeks:]
+	}
+	report.WriteString("\n" + periodChart(chartWeeks, "Week of 01-02", width))
+	return report.String()
+}
+
+// periodChart charts the watch time of periods labelled with layout
+func periodChart(periods []history.Period, layout string, width int) string {
+	labels := make([]string, len(periods))
+	values := make([]float64, len(periods))
+	texts := make([]string, len(periods))
+	for i, period := range periods {
+		labels[i] = period.Start.Format(layout)
+		values[i] = float64(period.WatchSeconds)
+		texts[i] = fmt.Sprintf("%d · %s", period.Plays, watchTime(period.WatchSeconds))
+	}
+	return barChart(labels, values, texts, width)
+}
+
+// barChart renders one bar per label, scaled to the largest value, followed by its text
+func barChart(labels []string, values []float64, texts []string, width int) string {
+	labelWidth, textWidth := 0, 0
+	var maxValue float64
+	for i := range labels {
+		labels[i] = truncate(labels[i], 20)
+		labelWidth = max(labelWidth, len([]rune(labels[i])))
+		textWidth = max(textWidth, len([]rune(texts[i])))
+		maxValue = max(maxValue, values[i])
+	}
+	barWidth := max(width-labelWidth-textWidth-4, minBarWidth)
+
+	lines := make([]string, len(labels))
+	for i := range labels {
+		lines[i] = fmt.Sprintf("%-*s %-*s  %s", labelWidth, labels[i], barWidth, format.Bar(values[i], maxValue, barWidth), texts[i])
+	}
+	return strings.Join(lines, "\n")
+}
+
+// watchTime renders an amount of watch time, such as 3h 12m
+func watchTime(seconds int64) string {
+	return format.Countdown(time.Duration(seconds) * time.Second)
+}
+
+func days(n int) string {
+	if n == 1 {
+		return "1 day"
+	}
+	return fmt.Sprintf("%d days", n)
+}
+
+// truncate cuts s to n runes, ending with an ellipsis when cut
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
+
+// clipLines keeps the first n lines of s
+func clipLines(s string, n int) string {
+	lines := strings.Split(s, "\n")
+	if n < 0 || len(lines) <= n {
+		return s
+	}
+	return strings.Join(lines[:n], "\n")
+}
@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Banh-Canh/ytui/internal/history"
+)
+
+func TestStatsReport(t *testing.T) {
+	day := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	stats := history.Stats{
+		From:          day,
+		Plays:         3,
+		Videos:        2,
+		WatchSeconds:  5400,
+		AverageLength: 1500,
+		Days: []history.Period{
+			{Start: day, Plays: 2, WatchSeconds: 3600},
+			{Start: day.AddDate(0, 0, 1), Plays: 1, WatchSeconds: 1800},
+		},
+		Weeks:         []history.Period{{Start: day, Plays: 3, WatchSeconds: 5400}},
+		TopChannels:   []history.ChannelStats{{Name: "A channel with a very long name", Plays: 3, WatchSeconds: 5400}},
+		CurrentStreak: 1,
+		LongestStreak: 2,
+	}
+
+	report := StatsReport(stats, 60)
+	assert.Contains(t, report, "Plays: 3 · Videos: 2 · Watched: 1h 30m")
+	assert.Contains(t, report, "Average video length: 25:00")
+	assert.Contains(t, report, "Streak: 1 day · Longest: 2 days")
+	assert.Contains(t, report, "A channel with a ve…")
+	assert.Contains(t, report, "Mon 03-03")
+	assert.Contains(t, report, "Week of 03-03")
+	for _, line := range strings.Split(report, "\n") {
+		assert.LessOrEqual(t, len([]rune(line)), 60, line)
+	}
+}
@@ -0,0 +1,92 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"go.uber.org/zap"
+
+	"github.com/Banh-Canh/ytui/internal/utils"
+	"github.com/Banh-Canh/ytui/pkg/youtube"
+)
+
+// videoInfoCache holds the full metadata of listed videos by ID. An entry exists once a
+// lookup started; it stays empty when the lookup failed.
+type videoInfoCache map[string]youtube.SearchResultItem
+
+type videoInfoLoadedMsg struct {
+	videos map[string]youtube.SearchResultItem
+}
+
+// needsVideoInfo reports whether a listed video lacks what the detail pane shows.
+// Playlist entries, for one, come without description or view count.
+func needsVideoInfo(video youtube.SearchResultItem) bool {
+	if video.IsUpcoming {
+		return false
+	}
+	return video.Description == "" || video.ViewCount == 0
+}
+
+// loadVideoInfo fetches the missing metadata of the visible page of the list in one batch
+func (m *model) loadVideoInfo() tea.Cmd {
+	if m.yt == nil || !m.isVideoView() || len(m.items) == 0 {
+		return nil
+	}
+
+	start := m.viewportOffset
+	end := start + m.viewport
+	if end > len(m.items) {
+		end = len(m.items)
+	}
+	if start >= end {
+		return nil
+	}
+	var videoIDs []string
+	for _, item := range m.items[start:end] {
+		video, ok := item.(youtube.SearchResultItem)
+		if !ok || !needsVideoInfo(video) {
+			continue
+		}
+		if _, requested := m.videoInfo[video.VideoID]; !requested {
+			m.videoInfo[video.VideoID] = youtube.SearchResultItem{}
+			videoIDs = append(videoIDs, video.VideoID)
+		}
+	}
+	if len(videoIDs) == 0 {
+		return nil
+	}
+
+	search := m.yt.Search()
+	return func() tea.Msg {
+		videos, err := search.VideoInfoBatch(videoIDs)
+		if err != nil {
+			utils.Logger.Debug("Failed to fetch video info.", zap.Int("videos", len(videoIDs)), zap.Error(err))
+		}
+		return videoInfoLoadedMsg{videos}
+	}
+}
+
+// withVideoInfo fills what a listed video lacks from its fetched metadata, keeping the
+// list's own fields such as the watch date or the Shorts flag
+func (m model) withVideoInfo(video youtube.SearchResultItem) youtube.SearchResultItem {
+	info, ok := m.videoInfo[video.VideoID]
+	if !ok || info.VideoID == "" {
+		return video
+	}
+	if video.Description == "" {
+		video.Description = info.Description
+	}
+	if video.ViewCount == 0 {
+		video.ViewCount = info.ViewCount
+		video.ViewCountText = info.ViewCountText
+	}
+	if video.LengthSeconds == 0 {
+		video.LengthSeconds = info.LengthSeconds
+	}
+	if video.Published == 0 {
+		video.Published = info.Published
+		video.PublishedText = info.PublishedText
+	}
+	if len(video.VideoThumbnails) == 0 {
+		video.VideoThumbnails = info.VideoThumbnails
+	}
+	return video
+}
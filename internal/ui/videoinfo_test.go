@@ -0,0 +1,29 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Banh-Canh/ytui/pkg/youtube"
+)
+
+func TestWithVideoInfo(t *testing.T) {
+	m := model{videoInfo: videoInfoCache{
+		"aaaaaaaaaaa": {VideoID: "aaaaaaaaaaa", Description: "Fetched", ViewCount: 1200, LengthSeconds: 300, ViewedDate: 99},
+		"bbbbbbbbbbb": {},
+	}}
+
+	listed := youtube.SearchResultItem{VideoID: "aaaaaaaaaaa", Title: "Listed", ViewedDate: 42, IsShort: true}
+	assert.True(t, needsVideoInfo(listed))
+	enriched := m.withVideoInfo(listed)
+	assert.Equal(t, "Fetched", enriched.Description)
+	assert.Equal(t, int64(1200), enriched.ViewCount)
+	assert.Equal(t, int32(300), enriched.LengthSeconds)
+	assert.Equal(t, int64(42), enriched.ViewedDate, "list fields are kept")
+	assert.True(t, enriched.IsShort)
+	assert.False(t, needsVideoInfo(enriched))
+
+	pending := youtube.SearchResultItem{VideoID: "bbbbbbbbbbb", Title: "Pending"}
+	assert.Equal(t, pending, m.withVideoInfo(pending), "failed or pending lookups change nothing")
+}
@@ -0,0 +1,108 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Banh-Canh/ytui/internal/history"
+	"github.com/Banh-Canh/ytui/pkg/youtube"
+)
+
+type watchStateMsg struct {
+	entries progressCache
+}
+
+type watchedMarkedMsg struct {
+	videoID string
+	entry   history.Entry
+	found   bool
+}
+
+// loadWatchState reads the history for the watched markers of video lists
+func loadWatchState(p profile) tea.Cmd {
+	return func() tea.Msg {
+		if !p.settings.GetBool("history.enable") {
+			return nil
+		}
+		configDir, err := p.dir()
+		if err != nil {
+			return nil
+		}
+		entries, err := history.Entries(configDir)
+		if err != nil {
+			// Lists work without markers
+			return nil
+		}
+		return watchStateMsg{entries}
+	}
+}
+
+func markWatched(video youtube.SearchResultItem, watched bool, p profile) tea.Cmd {
+	return func() tea.Msg {
+		configDir, err := p.dir()
+		if err != nil {
+			return statusMsg{err: err}
+		}
+		entry, found, err := history.SetWatched(video, watched, configDir)
+		if err != nil {
+			return statusMsg{err: fmt.Errorf("couldn't mark %s: %w", video.Title, err)}
+		}
+		if watched {
+			removeWatchedLater(video.VideoID, p)
+		}
+		return watchedMarkedMsg{videoID: video.VideoID, entry: entry, found: found}
+	}
+}
+
+// showsWatchMarkers reports whether the current view marks watched videos. The history
+// views tell it already.
+func (m model) showsWatchMarkers() bool {
+	return m.isVideoView() && m.currentView != HistoryView && m.currentView != ContinueWatchingView
+}
+
+// watchMarker renders ✓ for watched videos and the progress of partially watched ones
+func (m model) watchMarker(video youtube.SearchResultItem) string {
+	entry, ok := m.watched[video.VideoID]
+	if !ok {
+		return ""
+	}
+	if entry.Finished() {
+		return "✓"
+	}
+	if _, partial := entry.ResumePosition(); partial {
+		return fmt.Sprintf("◐ %d%%", int(entry.Fraction()*100))
+	}
+	return ""
+}
+
+// isWatched reports whether a listed video was watched to the end or marked so
+func (m model) isWatched(video youtube.SearchResultItem) bool {
+	entry, ok := m.watched[video.VideoID]
+	return ok && entry.Finished()
+}
+
+// withoutWatched drops the watched videos, keeping those partially watched
+func (m model) withoutWatched(videos []youtube.SearchResultItem) []youtube.SearchResultItem {
+	filtered := make([]youtube.SearchResultItem, 0, len(videos))
+	for _, video := range videos {
+		if !m.isWatched(video) {
+			filtered = append(filtered, video)
+		}
+	}
+	return filtered
+}
+
+// reshowVideos lists videoItems again after they changed, keeping the cursor where it was
+func (m *model) reshowVideos() {
+	cursor := m.cursor
+	m.showVideos()
+	if cursor >= len(m.items) {
+		cursor = len(m.items) - 1
+	}
+	if cursor > 0 {
+		m.cursor = cursor
+		m.updateViewport()
+		m.updateCurrentDetails()
+	}
+}
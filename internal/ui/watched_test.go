@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Banh-Canh/ytui/internal/history"
+	"github.com/Banh-Canh/ytui/pkg/youtube"
+)
+
+func TestWatchMarkers(t *testing.T) {
+	m := model{currentView: SubscribedView, width: 80, height: 24, videoItems: []youtube.SearchResultItem{
+		{VideoID: "aaaaaaaaaaa", Title: "Watched", LengthSeconds: 600},
+		{VideoID: "bbbbbbbbbbb", Title: "Halfway", LengthSeconds: 600},
+		{VideoID: "ccccccccccc", Title: "Marked unwatched", LengthSeconds: 600},
+		{VideoID: "ddddddddddd", Title: "New", LengthSeconds: 600},
+	}}
+	m.watched = progressCache{
+		"aaaaaaaaaaa": {},
+		"bbbbbbbbbbb": {Position: 5 * time.Minute, Length: 10 * time.Minute},
+		"ccccccccccc": {Unwatched: true},
+	}
+
+	assert.Equal(t, "✓", m.watchMarker(m.videoItems[0]))
+	assert.Equal(t, "◐ 50%", m.watchMarker(m.videoItems[1]))
+	assert.Empty(t, m.watchMarker(m.videoItems[2]))
+	assert.Empty(t, m.watchMarker(m.videoItems[3]))
+
+	m.hideWatched = true
+	m.showVideos()
+	require.Len(t, m.items, 3, "only the finished video is hidden")
+	assert.Equal(t, "bbbbbbbbbbb", m.items[0].(youtube.SearchResultItem).VideoID)
+
+	m.cursor = 2
+	m.watched["ccccccccccc"] = history.Entry{}
+	m.reshowVideos()
+	require.Len(t, m.items, 2)
+	assert.Equal(t, 1, m.cursor, "the cursor stays in the list")
+}
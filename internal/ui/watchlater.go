@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Banh-Canh/ytui/internal/watchlater"
+	"github.com/Banh-Canh/ytui/pkg/youtube"
+)
+
+type watchLaterMsg struct {
+	videos []youtube.SearchResultItem
+}
+
+type watchLaterToggledMsg struct {
+	video youtube.SearchResultItem
+	added bool
+}
+
+func loadWatchLater(p profile) tea.Cmd {
+	return func() tea.Msg {
+		configDir, err := p.dir()
+		if err != nil {
+			return errMsg{err}
+		}
+		list, err := watchlater.Load(configDir)
+		if err != nil {
+			return errMsg{err}
+		}
+		return watchLaterMsg{list.Videos()}
+	}
+}
+
+func toggleWatchLater(video youtube.SearchResultItem, p profile) tea.Cmd {
+	return func() tea.Msg {
+		configDir, err := p.dir()
+		if err != nil {
+			return statusMsg{err: err}
+		}
+		added, err := watchlater.Toggle(video, configDir)
+		if err != nil {
+			return statusMsg{err: err}
+		}
+		return watchLaterToggledMsg{video: video, added: added}
+	}
+}
+
+// saveWatchLater runs a change of the queue, reporting only failures
+func saveWatchLater(p profile, change func(configDir string) error) tea.Cmd {
+	return func() tea.Msg {
+		configDir, err := p.dir()
+		if err != nil {
+			return statusMsg{err: err}
+		}
+		if err := change(configDir); err != nil {
+			return statusMsg{err: err}
+		}
+		return nil
+	}
+}
+
+// removeWatchedLater drops a video played to the end from the queue, unless disabled
+func removeWatchedLater(videoID string, p profile) {
+	if p.settings.GetBool("watch_later.remove_watched") {
+		watchlater.Remove([]string{videoID}, p.configDir) // nolint:errcheck
+	}
+}
+
+// handleWatchLaterKey handles the keys of WatchLaterView that reorder and remove videos,
+// reporting false for the others
+func (m model) handleWatchLaterKey(msg tea.KeyMsg) (model, tea.Cmd, bool) {
+	if m.currentDetails == nil {
+		return m, nil, false
+	}
+	videoID := m.currentDetails.VideoID
+	switch msg.String() {
+	case "K", "J":
+		offset := 1
+		if msg.String() == "K" {
+			offset = -1
+		}
+		to := min(max(m.cursor+offset, 0), len(m.videoItems)-1)
+		if to == m.cursor {
+			return m, nil, true
+		}
+		video := m.videoItems[m.cursor]
+		m.videoItems = slices.Insert(slices.Delete(m.videoItems, m.cursor, m.cursor+1), to, video)
+		m.reshowVideos()
+		m.cursor = to
+		m.updateViewport()
+		m.updateCurrentDetails()
+		return m, saveWatchLater(m.profile, func(configDir string) error {
+			return watchlater.Move(videoID, offset, configDir)
+		}), true
+	case "x":
+		m.dropFromWatchLater(videoID)
+		m.status = "✓ Removed from Watch Later"
+		return m, saveWatchLater(m.profile, func(configDir string) error {
+			return watchlater.Remove([]string{videoID}, configDir)
+		}), true
+	}
+	return m, nil, false
+}
+
+// dropFromWatchLater removes a video from the listed queue
+func (m *model) dropFromWatchLater(videoID string) {
+	m.videoItems = slices.DeleteFunc(m.videoItems, func(video youtube.SearchResultItem) bool {
+		return video.VideoID == videoID
+	})
+	m.reshowVideos()
+}
+
+// watchLaterStatus tells what toggling a video did
+func watchLaterStatus(msg watchLaterToggledMsg) string {
+	if msg.added {
+		return fmt.Sprintf("✓ Added %s to Watch Later", msg.video.Title)
+	}
+	return fmt.Sprintf("✓ Removed %s from Watch Later", msg.video.Title)
+}
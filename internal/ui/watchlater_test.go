@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Banh-Canh/ytui/pkg/youtube"
+)
+
+func watchLaterIDs(m model) []string {
+	var videoIDs []string
+	for _, item := range m.items {
+		videoIDs = append(videoIDs, item.(youtube.SearchResultItem).VideoID)
+	}
+	return videoIDs
+}
+
+func TestWatchLater_ReorderAndRemove(t *testing.T) {
+	m := model{currentView: WatchLaterView, width: 80, height: 24, videoItems: []youtube.SearchResultItem{
+		{VideoID: "aaaaaaaaaaa", Title: "First"},
+		{VideoID: "bbbbbbbbbbb", Title: "Second"},
+		{VideoID: "ccccccccccc", Title: "Third"},
+	}}
+	m.showVideos()
+
+	m, cmd, handled := m.handleWatchLaterKey(runes("J"))
+	require.True(t, handled)
+	assert.NotNil(t, cmd, "the new order is saved")
+	assert.Equal(t, []string{"bbbbbbbbbbb", "aaaaaaaaaaa", "ccccccccccc"}, watchLaterIDs(m))
+	assert.Equal(t, 1, m.cursor, "the cursor follows the video")
+	assert.Equal(t, "aaaaaaaaaaa", m.currentDetails.VideoID)
+
+	m, _, _ = m.handleWatchLaterKey(runes("K"))
+	m, cmd, _ = m.handleWatchLaterKey(runes("K"))
+	assert.Nil(t, cmd, "already first")
+	assert.Equal(t, []string{"aaaaaaaaaaa", "bbbbbbbbbbb", "ccccccccccc"}, watchLaterIDs(m))
+
+	m.cursor = 2
+	m.updateCurrentDetails()
+	m, _, handled = m.handleWatchLaterKey(runes("x"))
+	require.True(t, handled)
+	assert.Equal(t, []string{"aaaaaaaaaaa", "bbbbbbbbbbb"}, watchLaterIDs(m))
+	assert.Equal(t, 1, m.cursor)
+
+	_, _, handled = m.handleWatchLaterKey(runes("p"))
+	assert.False(t, handled, "common bindings stay available")
+}
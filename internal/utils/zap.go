@@ -2,10 +2,12 @@ package utils
 
 import (
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 
 	"go.uber.org/zap"
+	"go.uber.org/zap/exp/zapslog"
 	"go.uber.org/zap/zapcore"
 )
 
@@ -39,3 +41,8 @@ func InitializeLogger(logLevel zapcore.Level, logFilePath string) {
 	}
 	defer Logger.Sync() //nolint:all
 }
+
+// SlogLogger returns a slog logger writing to Logger, for libraries logging through slog
+func SlogLogger() *slog.Logger {
+	return slog.New(zapslog.NewHandler(Logger.Core()))
+}
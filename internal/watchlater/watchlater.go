@@ -0,0 +1,147 @@
+// Package watchlater keeps the Watch Later queue of a profile, a local list of videos to
+// play later that needs no Google account. The queue is stored in the profile's history
+// database, which ytui instances take turns to change.
+package watchlater
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Banh-Canh/ytui/internal/history"
+	"github.com/Banh-Canh/ytui/internal/utils"
+	"github.com/Banh-Canh/ytui/pkg/youtube"
+)
+
+// Item is a video of the queue
+type Item struct {
+	Video   youtube.SearchResultItem `json:"video"`
+	AddedAt time.Time                `json:"addedAt"`
+}
+
+// List is the Watch Later queue, in the order the videos are to be played
+type List struct {
+	Items []Item
+}
+
+// Load reads the queue of a config directory. A queue never saved is empty.
+func Load(configDir string) (*List, error) {
+	data, err := history.WatchLater(configDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Watch Later: %w", err)
+	}
+	return decode(data)
+}
+
+// decode parses a queue as stored in the database
+func decode(data []byte) (*List, error) {
+	list := &List{}
+	if data == nil {
+		return list, nil
+	}
+	if err := json.Unmarshal(data, &list.Items); err != nil {
+		return nil, fmt.Errorf("failed to parse Watch Later: %w", err)
+	}
+	return list, nil
+}
+
+// Videos returns the videos of the queue in order
+func (l *List) Videos() []youtube.SearchResultItem {
+	videos := make([]youtube.SearchResultItem, len(l.Items))
+	for i, item := range l.Items {
+		videos[i] = item.Video
+	}
+	return videos
+}
+
+func (l *List) index(videoID string) int {
+	return slices.IndexFunc(l.Items, func(item Item) bool { return item.Video.VideoID == videoID })
+}
+
+// Contains reports whether a video is queued
+func (l *List) Contains(videoID string) bool {
+	return l.index(videoID) >= 0
+}
+
+// Add queues a video last, false when it already is
+func (l *List) Add(video youtube.SearchResultItem, at time.Time) bool {
+	if l.Contains(video.VideoID) {
+		return false
+	}
+	l.Items = append(l.Items, Item{Video: video, AddedAt: at})
+	return true
+}
+
+// Remove drops videos from the queue and returns how many were queued
+func (l *List) Remove(videoIDs ...string) int {
+	before := len(l.Items)
+	l.Items = slices.DeleteFunc(l.Items, func(item Item) bool { return slices.Contains(videoIDs, item.Video.VideoID) })
+	return before - len(l.Items)
+}
+
+// Move moves a video by offset places, up when negative, stopping at either end. It
+// returns the new index of the video, -1 when it is not queued.
+func (l *List) Move(videoID string, offset int) int {
+	from := l.index(videoID)
+	if from < 0 {
+		return -1
+	}
+	to := min(max(from+offset, 0), len(l.Items)-1)
+	item := l.Items[from]
+	l.Items = slices.Insert(slices.Delete(l.Items, from, from+1), to, item)
+	return to
+}
+
+// update applies change to the queue of a config directory and saves it if it reports
+// a change, in one transaction of the database
+func update(configDir string, change func(*List) bool) error {
+	err := history.UpdateWatchLater(func(data []byte) ([]byte, error) {
+		list, err := decode(data)
+		if err != nil {
+			return nil, err
+		}
+		if !change(list) {
+			return nil, nil
+		}
+		if list.Items == nil {
+			list.Items = []Item{}
+		}
+		return json.Marshal(list.Items)
+	}, configDir)
+	if err != nil {
+		utils.Logger.Error("Failed to save Watch Later.", zap.Error(err))
+		return fmt.Errorf("failed to save Watch Later: %w", err)
+	}
+	return nil
+}
+
+// Toggle queues a video of a config directory, or drops it when it is already queued.
+// It reports whether the video was added.
+func Toggle(video youtube.SearchResultItem, configDir string) (bool, error) {
+	added := false
+	err := update(configDir, func(list *List) bool {
+		if list.Remove(video.VideoID) > 0 {
+			return true
+		}
+		added = list.Add(video, time.Now())
+		return added
+	})
+	return added && err == nil, err
+}
+
+// Remove drops videos from the queue of a config directory
+func Remove(videoIDs []string, configDir string) error {
+	return update(configDir, func(list *List) bool {
+		return list.Remove(videoIDs...) > 0
+	})
+}
+
+// Move moves a video of the queue of a config directory by offset places
+func Move(videoID string, offset int, configDir string) error {
+	return update(configDir, func(list *List) bool {
+		return list.Move(videoID, offset) >= 0
+	})
+}
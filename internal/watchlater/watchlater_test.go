@@ -0,0 +1,142 @@
+package watchlater
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/Banh-Canh/ytui/internal/history"
+	"github.com/Banh-Canh/ytui/internal/utils"
+	"github.com/Banh-Canh/ytui/pkg/youtube"
+)
+
+func TestMain(m *testing.M) {
+	// Mock zap.Logger to avoid breaking tests.
+	utils.Logger = zap.NewNop()
+
+	// Run the tests
+	m.Run()
+}
+
+func video(id string) youtube.SearchResultItem {
+	return youtube.SearchResultItem{Type: "video", VideoID: id, Title: "Video " + id}
+}
+
+func ids(list *List) []string {
+	var videoIDs []string
+	for _, video := range list.Videos() {
+		videoIDs = append(videoIDs, video.VideoID)
+	}
+	return videoIDs
+}
+
+func TestList_AddRemoveMove(t *testing.T) {
+	list := &List{}
+	at := time.Date(2025, 3, 1, 20, 0, 0, 0, time.UTC)
+	for _, id := range []string{"a", "b", "c"} {
+		assert.True(t, list.Add(video(id), at))
+	}
+	assert.False(t, list.Add(video("a"), at), "already queued")
+
+	assert.Equal(t, 0, list.Move("c", -2))
+	assert.Equal(t, []string{"c", "a", "b"}, ids(list))
+	assert.Equal(t, 2, list.Move("c", 5), "stops at the end")
+	assert.Equal(t, []string{"a", "b", "c"}, ids(list))
+	assert.Equal(t, -1, list.Move("z", 1))
+
+	assert.Equal(t, 2, list.Remove("a", "c", "z"))
+	assert.Equal(t, []string{"b"}, ids(list))
+}
+
+func TestToggleAndReload(t *testing.T) {
+	dir := t.TempDir()
+	list, err := Load(dir)
+	require.NoError(t, err)
+	assert.Empty(t, list.Items, "no file yet")
+
+	added, err := Toggle(video("a"), dir)
+	require.NoError(t, err)
+	assert.True(t, added)
+	_, err = Toggle(video("b"), dir)
+	require.NoError(t, err)
+	require.NoError(t, Move("b", -1, dir))
+
+	list, err = Load(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "a"}, ids(list))
+	assert.Equal(t, "Video a", list.Items[1].Video.Title)
+
+	added, err = Toggle(video("a"), dir)
+	require.NoError(t, err)
+	assert.False(t, added, "toggled off")
+	require.NoError(t, Remove([]string{"b"}, dir))
+	list, err = Load(dir)
+	require.NoError(t, err)
+	assert.Empty(t, list.Items)
+	assert.NoFileExists(t, filepath.Join(dir, history.LegacyWatchLaterFileName), "kept in the history database")
+}
+
+func TestConcurrentChangesAreKept(t *testing.T) {
+	dir := t.TempDir()
+	videoIDs := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	for _, id := range videoIDs {
+		_, err := Toggle(video(id), dir)
+		require.NoError(t, err)
+	}
+
+	var wg sync.WaitGroup
+	for _, id := range videoIDs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if id < "e" {
+				assert.NoError(t, Remove([]string{id}, dir))
+			} else {
+				assert.NoError(t, Move(id, -len(videoIDs), dir))
+			}
+		}()
+	}
+	wg.Wait()
+
+	list, err := Load(dir)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"e", "f", "g", "h"}, ids(list), "no change lost")
+}
+
+func TestLoad_ImportsLegacyFile(t *testing.T) {
+	dir := t.TempDir()
+	legacy := `[{"video":{"videoId":"b","title":"Video b"},"addedAt":"2025-03-01T20:00:00Z"},{"video":{"videoId":"a"}}]`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, history.LegacyWatchLaterFileName), []byte(legacy), 0o600))
+
+	list, err := Load(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "a"}, ids(list))
+	assert.Equal(t, "Video b", list.Items[0].Video.Title)
+
+	require.NoError(t, Remove([]string{"b"}, dir))
+	list, err = Load(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, ids(list), "imported once")
+}
+
+func TestLoad_QuarantinesDamagedLegacyFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, history.LegacyWatchLaterFileName), []byte("[{"), 0o600))
+
+	list, err := Load(dir)
+	require.NoError(t, err, "a damaged queue must not fail every start")
+	assert.Empty(t, list.Items)
+	added, err := Toggle(video("a"), dir)
+	require.NoError(t, err)
+	assert.True(t, added)
+
+	quarantined, err := filepath.Glob(filepath.Join(dir, history.LegacyWatchLaterFileName+".corrupt-*"))
+	require.NoError(t, err)
+	assert.Len(t, quarantined, 1)
+}
@@ -0,0 +1,110 @@
+package youtube
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// YoutubeAPIBaseURL is the root of the YouTube Data API v3
+const YoutubeAPIBaseURL = "https://www.googleapis.com/youtube/v3"
+
+// ErrInsufficientScope is returned when the token lacks the scope an API call needs
+var ErrInsufficientScope = errors.New("the OAuth2 token lacks the required scope")
+
+// APIError is an error answered by the YouTube Data API
+type APIError struct {
+	StatusCode int
+	Message    string
+	Reason     string
+}
+
+func (e *APIError) Error() string {
+	if e.Reason != "" {
+		return fmt.Sprintf("YouTube API error %d (%s): %s", e.StatusCode, e.Reason, e.Message)
+	}
+	return fmt.Sprintf("YouTube API error %d: %s", e.StatusCode, e.Message)
+}
+
+// Is lets errors.Is(err, ErrInsufficientScope) match scope failures
+func (e *APIError) Is(target error) bool {
+	return target == ErrInsufficientScope && (e.Reason == "insufficientPermissions" || e.Reason == "ACCESS_TOKEN_SCOPE_INSUFFICIENT")
+}
+
+// googleErrorResponse is the error envelope of Google APIs
+type googleErrorResponse struct {
+	Error struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Status  string `json:"status"`
+		Errors  []struct {
+			Reason string `json:"reason"`
+		} `json:"errors"`
+		Details []struct {
+			Reason string `json:"reason"`
+		} `json:"details"`
+	} `json:"error"`
+}
+
+// doAPI sends an authenticated request to the YouTube Data API. payload is encoded as
+// JSON when not nil and the response is decoded into result when not nil.
+func (c *Client) doAPI(method, fullURL string, payload, result interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, fullURL, body)
+	if err != nil {
+		return err
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Load().Do(req)
+	if err != nil {
+		return fmt.Errorf("error calling YouTube API: %v", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response body: %v", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return parseAPIError(resp.StatusCode, respBody)
+	}
+
+	if result == nil || len(respBody) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, result); err != nil {
+		return fmt.Errorf("error parsing JSON: %v", err)
+	}
+	return nil
+}
+
+func parseAPIError(statusCode int, body []byte) error {
+	apiErr := &APIError{StatusCode: statusCode, Message: http.StatusText(statusCode)}
+
+	var envelope googleErrorResponse
+	if err := json.Unmarshal(body, &envelope); err == nil && envelope.Error.Message != "" {
+		apiErr.Message = envelope.Error.Message
+		if len(envelope.Error.Errors) > 0 {
+			apiErr.Reason = envelope.Error.Errors[0].Reason
+		}
+		if len(envelope.Error.Details) > 0 && envelope.Error.Details[0].Reason != "" {
+			apiErr.Reason = envelope.Error.Details[0].Reason
+		}
+	}
+	return apiErr
+}
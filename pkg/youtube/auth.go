@@ -2,125 +2,143 @@ package youtube
 
 import (
 	"context"
-	"encoding/json"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"log/slog"
+	"net"
 	"net/http"
-	"os"
+	"net/url"
 	"os/exec"
-	"path/filepath"
+	"strings"
+	"time"
 
 	"golang.org/x/oauth2"
 )
 
+const (
+	// GoogleRevokeURL is the endpoint used to revoke OAuth2 grants on logout
+	GoogleRevokeURL = "https://oauth2.googleapis.com/revoke"
+
+	// DefaultLoginTimeout is how long the loopback flow waits for the browser callback
+	DefaultLoginTimeout = 5 * time.Minute
+
+	oauthCallbackPath = "/oauth2callback"
+)
+
 // AuthService handles OAuth2 authentication
 type AuthService struct {
 	client *Client
 }
 
+// AuthResult is the outcome of an asynchronous authentication
+type AuthResult struct {
+	Client *http.Client
+	Err    error
+}
+
+// callbackResult is what the loopback server hands back to the waiting flow
+type callbackResult struct {
+	token *oauth2.Token
+	err   error
+}
+
 // NewAuthService creates a new auth service
 func (c *Client) Auth() *AuthService {
 	return &AuthService{client: c}
 }
 
-// AuthenticateAsync performs OAuth2 authentication asynchronously
-func (a *AuthService) AuthenticateAsync() (chan *http.Client, error) {
-	tokenFile := a.getTokenFilePath()
-	apiChan := make(chan *http.Client)
+// AuthenticateAsync performs OAuth2 authentication asynchronously.
+// The returned channel receives exactly one result and is then closed.
+func (a *AuthService) AuthenticateAsync() <-chan AuthResult {
+	resultChan := make(chan AuthResult, 1)
 
 	go func() {
-		defer close(apiChan)
-		
-		token, err := a.loadToken(tokenFile)
-		if err != nil || a.isTokenExpired(token) {
-			if err := a.startOAuthFlow(apiChan, tokenFile); err != nil {
-				return
-			}
-		} else {
-			// Check if token needs refreshing
-			if !token.Valid() && token.RefreshToken != "" {
-				refreshedToken, refreshErr := a.refreshToken(token)
-				if refreshErr != nil {
-					if err := a.startOAuthFlow(apiChan, tokenFile); err != nil {
-						return
-					}
-					return
-				}
-				
-				// Save the refreshed token
-				if saveErr := a.saveToken(tokenFile, refreshedToken); saveErr != nil {
-					// Log error but continue
-				}
-				token = refreshedToken
-			}
-			
-			client := a.client.oauth2Config.Client(context.Background(), token)
-			apiChan <- client
-		}
+		defer close(resultChan)
+		client, err := a.Authenticate()
+		resultChan <- AuthResult{Client: client, Err: err}
 	}()
-	
-	return apiChan, nil
+
+	return resultChan
 }
 
-// Authenticate performs OAuth2 authentication synchronously
+// Authenticate performs OAuth2 authentication synchronously and returns a client
+// sending authenticated requests through the configured transport and middleware
 func (a *AuthService) Authenticate() (*http.Client, error) {
-	clientChan, err := a.AuthenticateAsync()
+	source, err := a.TokenSource()
 	if err != nil {
 		return nil, err
 	}
-	
-	client := <-clientChan
-	if client == nil {
-		return nil, fmt.Errorf("authentication failed")
-	}
-	
-	return client, nil
+	return &http.Client{
+		Transport: &oauth2.Transport{Source: source, Base: a.client.transport},
+		Timeout:   a.client.timeout,
+	}, nil
 }
 
-func (a *AuthService) saveToken(filename string, token *oauth2.Token) error {
-	dir := filepath.Dir(filename)
-	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
-		return err
-	}
+// TokenSource performs OAuth2 authentication synchronously. A stored token is
+// reused (and refreshed if needed); otherwise the loopback browser flow is started.
+func (a *AuthService) TokenSource() (oauth2.TokenSource, error) {
+	store := a.client.tokenStore
 
-	file, err := os.Create(filename)
-	if err != nil {
-		return err
+	token, err := store.LoadToken()
+	if err == nil && !a.isTokenExpired(token) && a.hasRequiredScopes(token) {
+		// Check if token needs refreshing
+		if !token.Valid() && token.RefreshToken != "" {
+			refreshedToken, refreshErr := a.refreshToken(token)
+			if refreshErr != nil {
+				a.client.logger.Warn("Failed to refresh OAuth2 token, logging in again.", slog.Any("error", refreshErr))
+				token = nil
+			} else {
+				// A failed save only costs a refresh on the next start
+				if saveErr := store.SaveToken(refreshedToken); saveErr != nil {
+					a.client.logger.Warn("Failed to save refreshed OAuth2 token.", slog.Any("error", saveErr))
+				}
+				a.client.logger.Debug("Refreshed OAuth2 token.")
+				token = refreshedToken
+			}
+		}
+	} else {
+		if err != nil && !errors.Is(err, ErrNoToken) {
+			a.client.logger.Warn("Failed to load stored OAuth2 token.", slog.Any("error", err))
+		}
+		// Missing, dead, or granted for fewer scopes than configured: (re-)consent
+		token = nil
 	}
-	defer file.Close()
-
-	encoder := json.NewEncoder(file)
-	return encoder.Encode(token)
-}
 
-func (a *AuthService) loadToken(filename string) (*oauth2.Token, error) {
-	file, err := os.Open(filename)
-	if err != nil {
-		return nil, err
+	if token == nil {
+		a.client.logger.Info("Starting OAuth2 browser login.", slog.Any("scopes", a.client.oauth2Config.Scopes))
+		token, err = a.startOAuthFlow()
+		if err != nil {
+			return nil, fmt.Errorf("authentication failed: %w", err)
+		}
+		if err := store.SaveToken(token); err != nil {
+			return nil, fmt.Errorf("failed to save token: %w", err)
+		}
 	}
-	defer file.Close()
 
-	token := &oauth2.Token{}
-	decoder := json.NewDecoder(file)
-	if err := decoder.Decode(token); err != nil {
-		return nil, err
-	}
+	return a.client.tokenSource(token), nil
+}
 
-	return token, nil
+// oauth2Context makes the oauth2 package send its requests through the middleware
+func (c *Client) oauth2Context(ctx context.Context) context.Context {
+	return context.WithValue(ctx, oauth2.HTTPClient, c.baseClient)
 }
 
 func (a *AuthService) isTokenExpired(token *oauth2.Token) bool {
 	if token == nil {
 		return true
 	}
-	
+
 	if token.Valid() {
 		return false
 	}
-	
+
 	if token.RefreshToken != "" {
 		return false
 	}
-	
+
 	return true
 }
 
@@ -128,66 +146,209 @@ func (a *AuthService) refreshToken(token *oauth2.Token) (*oauth2.Token, error) {
 	if token.RefreshToken == "" {
 		return nil, fmt.Errorf("no refresh token available")
 	}
-	
-	tokenSource := a.client.oauth2Config.TokenSource(context.Background(), token)
-	newToken, err := tokenSource.Token()
+
+	newToken, err := a.client.tokenSource(token).Token()
 	if err != nil {
 		return nil, err
 	}
-	
+
+	// Refresh responses may omit the scope, the grant itself is unchanged
+	if grantedScope(newToken) == "" && grantedScope(token) != "" {
+		newToken = newToken.WithExtra(map[string]interface{}{"scope": grantedScope(token)})
+	}
+
 	return newToken, nil
 }
 
-func (a *AuthService) getTokenFilePath() string {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return ""
+// hasRequiredScopes reports whether the token was granted every configured scope.
+// Tokens saved before scopes were recorded were always read-only grants.
+func (a *AuthService) hasRequiredScopes(token *oauth2.Token) bool {
+	granted := strings.Fields(grantedScope(token))
+	if len(granted) == 0 {
+		granted = []string{ScopeReadOnly}
+	}
+
+	for _, required := range a.client.oauth2Config.Scopes {
+		if !containsScope(granted, required) && !(required == ScopeReadOnly && containsScope(granted, ScopeManage)) {
+			return false
+		}
 	}
-	return filepath.Join(homeDir, ".config", "ytui", "credentials.json")
+	return true
 }
 
-func (a *AuthService) startOAuthFlow(apiChan chan *http.Client, tokenFile string) error {
-	authURL := a.client.oauth2Config.AuthCodeURL("state-token", oauth2.AccessTypeOffline, oauth2.ApprovalForce)
-	
-	err := exec.Command("xdg-open", authURL).Start()
+func containsScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrRevokeFailed is wrapped by Logout when the token was deleted locally but could not
+// be revoked at Google, for instance offline
+var ErrRevokeFailed = errors.New("failed to revoke token")
+
+// Logout revokes the stored token at Google and removes it from the token store. The
+// token is deleted even when revoking it fails, which is then reported as ErrRevokeFailed.
+func (a *AuthService) Logout(ctx context.Context) error {
+	store := a.client.tokenStore
+	token, err := store.LoadToken()
 	if err != nil {
-		return err
+		if errors.Is(err, ErrNoToken) {
+			return nil
+		}
+		return fmt.Errorf("failed to load token: %w", err)
 	}
 
-	return a.startOAuthServer(apiChan, tokenFile)
+	revokeErr := a.revokeToken(ctx, token)
+	a.client.clearTokenSource()
+	if err := store.DeleteToken(); err != nil {
+		return errors.Join(fmt.Errorf("failed to delete token: %w", err), revokeErr)
+	}
+	if revokeErr != nil {
+		return fmt.Errorf("%w: %w", ErrRevokeFailed, revokeErr)
+	}
+	return nil
 }
 
-func (a *AuthService) startOAuthServer(apiChan chan *http.Client, tokenFile string) error {
-	http.HandleFunc("/oauth2callback", func(w http.ResponseWriter, r *http.Request) {
-		a.handleOAuthCallback(w, r, apiChan, tokenFile)
-	})
+// revokeToken invalidates the grant; revoking the refresh token also kills its access tokens
+func (a *AuthService) revokeToken(ctx context.Context, token *oauth2.Token) error {
+	value := token.RefreshToken
+	if value == "" {
+		value = token.AccessToken
+	}
+	if value == "" {
+		return nil
+	}
+
+	form := url.Values{}
+	form.Set("token", value)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, GoogleRevokeURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := a.client.baseClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error revoking token: %w", err)
+	}
+	defer resp.Body.Close()
 
-	return http.ListenAndServe(":8080", nil)
+	// Google answers 400 invalid_token for grants that are already revoked or expired
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusBadRequest {
+		return fmt.Errorf("received non-200 response: %d %s", resp.StatusCode, resp.Status)
+	}
+	return nil
 }
 
-func (a *AuthService) handleOAuthCallback(w http.ResponseWriter, r *http.Request, apiChan chan *http.Client, tokenFile string) {
-	code := r.URL.Query().Get("code")
-	if code == "" {
-		http.Error(w, "Authorization code not found", http.StatusBadRequest)
-		return
+// startOAuthFlow runs the loopback flow: it serves the callback on an ephemeral
+// localhost port, opens the consent page and waits for the code until the login timeout.
+func (a *AuthService) startOAuthFlow() (*oauth2.Token, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for OAuth2 callback: %w", err)
 	}
 
-	token, err := a.client.oauth2Config.Exchange(context.Background(), code)
+	oauthConfig := *a.client.oauth2Config
+	oauthConfig.RedirectURL = fmt.Sprintf("http://%s%s", listener.Addr().String(), oauthCallbackPath)
+
+	state, err := randomState()
 	if err != nil {
-		http.Error(w, "Failed to exchange authorization code for token", http.StatusInternalServerError)
-		return
+		listener.Close()
+		return nil, err
 	}
+	verifier := oauth2.GenerateVerifier()
 
-	if err := a.saveToken(tokenFile, token); err != nil {
-		http.Error(w, "Failed to save token", http.StatusInternalServerError)
-		return
+	results := make(chan callbackResult, 1)
+	mux := http.NewServeMux()
+	mux.Handle(oauthCallbackPath, a.callbackHandler(&oauthConfig, state, verifier, results))
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
 	}
+	go server.Serve(listener) //nolint:errcheck // returns ErrServerClosed on shutdown
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(ctx) //nolint:errcheck
+	}()
 
-	client := a.client.oauth2Config.Client(context.Background(), token)
-	apiChan <- client
+	authURL := oauthConfig.AuthCodeURL(
+		state,
+		oauth2.AccessTypeOffline,
+		oauth2.ApprovalForce,
+		oauth2.S256ChallengeOption(verifier),
+		// Upgrading a read-only grant keeps the scopes granted before
+		oauth2.SetAuthURLParam("include_granted_scopes", "true"),
+	)
+	if err := exec.Command("xdg-open", authURL).Start(); err != nil {
+		return nil, fmt.Errorf("failed to open browser, visit %s manually: %w", authURL, err)
+	}
 
-	w.Header().Set("Content-Type", "text/html")
-	w.Write([]byte(`
+	timeout := a.client.loginTimeout
+	if timeout <= 0 {
+		timeout = DefaultLoginTimeout
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case result := <-results:
+		return result.token, result.err
+	case <-timer.C:
+		return nil, fmt.Errorf("timed out after %s waiting for the OAuth2 callback", timeout)
+	}
+}
+
+// callbackHandler verifies the state, exchanges the code with the PKCE verifier and
+// reports the first completed attempt on results. Later hits are answered but ignored.
+func (a *AuthService) callbackHandler(
+	oauthConfig *oauth2.Config,
+	state, verifier string,
+	results chan<- callbackResult,
+) http.Handler {
+	report := func(result callbackResult) {
+		select {
+		case results <- result:
+		default:
+		}
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		if subtle.ConstantTimeCompare([]byte(query.Get("state")), []byte(state)) != 1 {
+			// Not reported: a stray or forged request must not abort the pending login
+			http.Error(w, "Invalid OAuth2 state", http.StatusBadRequest)
+			return
+		}
+
+		if authErr := query.Get("error"); authErr != "" {
+			report(callbackResult{err: fmt.Errorf("authorization denied: %s", authErr)})
+			http.Error(w, "Authorization denied", http.StatusForbidden)
+			return
+		}
+
+		code := query.Get("code")
+		if code == "" {
+			report(callbackResult{err: errors.New("authorization code not found in callback")})
+			http.Error(w, "Authorization code not found", http.StatusBadRequest)
+			return
+		}
+
+		token, err := oauthConfig.Exchange(a.client.oauth2Context(r.Context()), code, oauth2.VerifierOption(verifier))
+		if err != nil {
+			report(callbackResult{err: fmt.Errorf("failed to exchange authorization code for token: %w", err)})
+			http.Error(w, "Failed to exchange authorization code for token", http.StatusInternalServerError)
+			return
+		}
+
+		report(callbackResult{token: token})
+
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(`
 		<!DOCTYPE html>
 		<html lang="en">
 		<head>
@@ -202,4 +363,14 @@ func (a *AuthService) handleOAuthCallback(w http.ResponseWriter, r *http.Request
 		</body>
 		</html>
 	`))
-}
\ No newline at end of file
+	})
+}
+
+// randomState returns an unguessable value for the OAuth2 state parameter
+func randomState() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate OAuth2 state: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
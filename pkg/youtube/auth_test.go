@@ -0,0 +1,201 @@
+package youtube
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+// newTokenServer returns a fake token endpoint that records the PKCE verifier it receives
+func newTokenServer(t *testing.T, gotVerifier *string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		*gotVerifier = r.PostForm.Get("code_verifier")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"access","refresh_token":"refresh","token_type":"Bearer","expires_in":3600}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func callback(handler http.Handler, params url.Values) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, oauthCallbackPath+"?"+params.Encode(), nil)
+	handler.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestCallbackHandler_ExchangesCodeWithVerifier(t *testing.T) {
+	var gotVerifier string
+	tokenServer := newTokenServer(t, &gotVerifier)
+	oauthConfig := &oauth2.Config{
+		ClientID: "client",
+		Endpoint: oauth2.Endpoint{TokenURL: tokenServer.URL},
+	}
+
+	results := make(chan callbackResult, 1)
+	auth := NewClient(Config{}).Auth()
+	handler := auth.callbackHandler(oauthConfig, "expected-state", "the-verifier", results)
+
+	recorder := callback(handler, url.Values{"state": {"expected-state"}, "code": {"abc"}})
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	result := <-results
+	require.NoError(t, result.err)
+	assert.Equal(t, "access", result.token.AccessToken)
+	assert.Equal(t, "refresh", result.token.RefreshToken)
+	assert.Equal(t, "the-verifier", gotVerifier)
+}
+
+func TestCallbackHandler_RejectsWrongState(t *testing.T) {
+	results := make(chan callbackResult, 1)
+	auth := NewClient(Config{}).Auth()
+	handler := auth.callbackHandler(&oauth2.Config{}, "expected-state", "verifier", results)
+
+	recorder := callback(handler, url.Values{"state": {"forged"}, "code": {"abc"}})
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	// A forged callback must leave the pending login untouched
+	assert.Empty(t, results)
+}
+
+func TestCallbackHandler_ReportsDenial(t *testing.T) {
+	results := make(chan callbackResult, 1)
+	auth := NewClient(Config{}).Auth()
+	handler := auth.callbackHandler(&oauth2.Config{}, "expected-state", "verifier", results)
+
+	recorder := callback(handler, url.Values{"state": {"expected-state"}, "error": {"access_denied"}})
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+
+	result := <-results
+	require.Error(t, result.err)
+	assert.Contains(t, result.err.Error(), "access_denied")
+}
+
+func TestRandomState_IsUnique(t *testing.T) {
+	first, err := randomState()
+	require.NoError(t, err)
+	second, err := randomState()
+	require.NoError(t, err)
+	assert.NotEqual(t, first, second)
+	assert.Len(t, first, 43)
+}
+
+func TestHasRequiredScopes(t *testing.T) {
+	readOnly := &oauth2.Token{AccessToken: "a"}
+	manage := (&oauth2.Token{AccessToken: "a"}).WithExtra(map[string]interface{}{"scope": ScopeManage})
+
+	readAuth := NewClient(Config{}).Auth()
+	writeAuth := NewClient(Config{WriteAccess: true}).Auth()
+
+	// Tokens without a recorded scope are legacy read-only grants
+	assert.True(t, readAuth.hasRequiredScopes(readOnly))
+	assert.False(t, writeAuth.hasRequiredScopes(readOnly), "write access must trigger re-consent")
+	assert.True(t, writeAuth.hasRequiredScopes(manage))
+	assert.True(t, readAuth.hasRequiredScopes(manage), "the manage scope covers read-only calls")
+}
+
+func TestMarshalToken_KeepsScope(t *testing.T) {
+	token := (&oauth2.Token{AccessToken: "a", RefreshToken: "r"}).WithExtra(map[string]interface{}{"scope": ScopeManage})
+
+	data, err := MarshalToken(token)
+	require.NoError(t, err)
+
+	decoded, err := UnmarshalToken(data)
+	require.NoError(t, err)
+	assert.Equal(t, "r", decoded.RefreshToken)
+	assert.Equal(t, ScopeManage, grantedScope(decoded))
+}
+
+func TestParseAPIError_InsufficientScope(t *testing.T) {
+	body := []byte(`{"error":{"code":403,"message":"Request had insufficient authentication scopes.",` +
+		`"errors":[{"reason":"insufficientPermissions"}]}}`)
+
+	err := parseAPIError(http.StatusForbidden, body)
+	assert.ErrorIs(t, err, ErrInsufficientScope)
+	assert.Contains(t, err.Error(), "insufficient authentication scopes")
+}
+
+// memoryTokenStore holds the token in memory
+type memoryTokenStore struct {
+	token *oauth2.Token
+}
+
+func (s *memoryTokenStore) LoadToken() (*oauth2.Token, error) {
+	if s.token == nil {
+		return nil, ErrNoToken
+	}
+	return s.token, nil
+}
+
+func (s *memoryTokenStore) SaveToken(token *oauth2.Token) error {
+	s.token = token
+	return nil
+}
+
+func (s *memoryTokenStore) DeleteToken() error {
+	s.token = nil
+	return nil
+}
+
+func TestLogout_DeletesTokenWhenRevokeFails(t *testing.T) {
+	store := &memoryTokenStore{token: &oauth2.Token{RefreshToken: "refresh"}}
+	offline := RoundTripperFunc(func(*http.Request) (*http.Response, error) {
+		return nil, errors.New("network is unreachable")
+	})
+	auth := NewClient(Config{TokenStore: store}, WithTransport(offline)).Auth()
+
+	err := auth.Logout(context.Background())
+	assert.ErrorIs(t, err, ErrRevokeFailed)
+	assert.Nil(t, store.token, "the local token is deleted anyway")
+
+	assert.NoError(t, auth.Logout(context.Background()), "nothing left to log out")
+}
+
+// countingTokenStore counts the loads of the token it holds
+type countingTokenStore struct {
+	memoryTokenStore
+	loads atomic.Int32
+}
+
+func (s *countingTokenStore) LoadToken() (*oauth2.Token, error) {
+	s.loads.Add(1)
+	return s.memoryTokenStore.LoadToken()
+}
+
+func TestAuthenticate_SetsTheTokenSourceOnce(t *testing.T) {
+	store := &countingTokenStore{memoryTokenStore: memoryTokenStore{
+		token: &oauth2.Token{AccessToken: "access", Expiry: time.Now().Add(time.Hour)},
+	}}
+	offline := RoundTripperFunc(func(*http.Request) (*http.Response, error) {
+		return nil, errors.New("network is unreachable")
+	})
+	yt := New(Config{TokenStore: store}, WithTransport(offline))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, yt.Authenticate())
+			assert.NotNil(t, yt.Client().GetHTTPClient())
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), store.loads.Load(), "later calls reuse the token source")
+	authenticated := yt.Client().GetHTTPClient()
+
+	assert.ErrorIs(t, yt.Auth().Logout(context.Background()), ErrRevokeFailed)
+	assert.NotSame(t, authenticated, yt.Client().GetHTTPClient(), "logging out drops the token source")
+}
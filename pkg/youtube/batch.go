@@ -0,0 +1,93 @@
+package youtube
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultBatchConcurrency bounds the VideoInfo requests a batch runs at once
+const DefaultBatchConcurrency = 4
+
+// BatchError reports the IDs of a batch that failed; the others were still returned
+type BatchError struct {
+	Errors map[string]error
+}
+
+func (e *BatchError) Error() string {
+	ids := make([]string, 0, len(e.Errors))
+	for id := range e.Errors {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	messages := make([]string, 0, len(ids))
+	for _, id := range ids {
+		messages = append(messages, fmt.Sprintf("%s: %v", id, e.Errors[id]))
+	}
+	return fmt.Sprintf("failed to fetch %d videos: %s", len(ids), strings.Join(messages, "; "))
+}
+
+// Unwrap lets errors.Is and errors.As look at the per-video errors
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// VideoInfoBatch retrieves the information of several videos, DefaultBatchConcurrency
+// at a time. Duplicate IDs, and IDs already being fetched by another call on the same
+// client, are only requested once. Videos that could be fetched are returned even when
+// others failed, which are then listed in a *BatchError.
+func (s *SearchService) VideoInfoBatch(videoIDs []string) (map[string]SearchResultItem, error) {
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		videos = make(map[string]SearchResultItem, len(videoIDs))
+		failed = make(map[string]error)
+		slots  = make(chan struct{}, DefaultBatchConcurrency)
+		seen   = make(map[string]bool, len(videoIDs))
+	)
+	for _, videoID := range videoIDs {
+		if seen[videoID] {
+			continue
+		}
+		seen[videoID] = true
+
+		wg.Add(1)
+		go func(videoID string) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			video, err := s.sharedVideoInfo(videoID)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failed[videoID] = err
+				return
+			}
+			videos[videoID] = video
+		}(videoID)
+	}
+	wg.Wait()
+
+	if len(failed) > 0 {
+		return videos, &BatchError{Errors: failed}
+	}
+	return videos, nil
+}
+
+// sharedVideoInfo joins a VideoInfo request already in flight for the same video
+func (s *SearchService) sharedVideoInfo(videoID string) (SearchResultItem, error) {
+	value, err, _ := s.client.videoInfoCalls.Do(videoID, func() (interface{}, error) {
+		return s.VideoInfo(videoID)
+	})
+	if err != nil {
+		return SearchResultItem{}, err
+	}
+	return value.(SearchResultItem), nil
+}
@@ -0,0 +1,92 @@
+package youtube
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVideoInfoBatch_PartialResults(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests = map[string]int{}
+		running  int32
+		peak     int32
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			seen := atomic.LoadInt32(&peak)
+			if current <= seen || atomic.CompareAndSwapInt32(&peak, seen, current) {
+				break
+			}
+		}
+
+		videoID := strings.TrimPrefix(r.URL.Path, "/api/v1/videos/")
+		mu.Lock()
+		requests[videoID]++
+		mu.Unlock()
+		if videoID == "missing0000" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"title":"Video ` + videoID + `"}`))
+	}))
+	defer server.Close()
+
+	ids := []string{"aaaaaaaaaaa", "bbbbbbbbbbb", "aaaaaaaaaaa", "missing0000", "ccccccccccc", "ddddddddddd", "eeeeeeeeeee"}
+	videos, err := NewClient(Config{InvidiousURL: server.URL}).Search().VideoInfoBatch(ids)
+
+	var batchErr *BatchError
+	require.True(t, errors.As(err, &batchErr))
+	assert.Contains(t, batchErr.Errors, "missing0000")
+	assert.Len(t, batchErr.Errors, 1)
+
+	assert.Len(t, videos, 5)
+	assert.Equal(t, "Video aaaaaaaaaaa", videos["aaaaaaaaaaa"].Title)
+	assert.Equal(t, "aaaaaaaaaaa", videos["aaaaaaaaaaa"].VideoID)
+	assert.Equal(t, 1, requests["aaaaaaaaaaa"], "duplicate IDs are fetched once")
+	assert.LessOrEqual(t, int(atomic.LoadInt32(&peak)), DefaultBatchConcurrency)
+}
+
+func TestVideoInfoBatch_SharesInFlightRequests(t *testing.T) {
+	var requests int32
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		started <- struct{}{}
+		<-release
+		w.Write([]byte(`{"title":"Video"}`))
+	}))
+	defer server.Close()
+
+	search := NewClient(Config{InvidiousURL: server.URL}).Search()
+	var wg sync.WaitGroup
+	batch := func() {
+		defer wg.Done()
+		videos, err := search.VideoInfoBatch([]string{"aaaaaaaaaaa"})
+		assert.NoError(t, err)
+		assert.Equal(t, "Video", videos["aaaaaaaaaaa"].Title)
+	}
+
+	wg.Add(2)
+	go batch()
+	<-started
+	go batch()
+	// Give the second batch time to join the request in flight
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
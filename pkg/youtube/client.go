@@ -1,64 +1,163 @@
 package youtube
 
 import (
-	"context"
+	"log/slog"
 	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// ScopeReadOnly allows reading the account's subscriptions and playlists
+	ScopeReadOnly = "https://www.googleapis.com/auth/youtube.readonly"
+	// ScopeManage additionally allows subscribing, rating and editing playlists
+	ScopeManage = "https://www.googleapis.com/auth/youtube"
 )
 
 // Client represents the YouTube API client with all necessary functionality
 type Client struct {
-	httpClient   *http.Client
+	httpClient   atomic.Pointer[http.Client] // YouTube Data API client, authenticated once logged in
+	baseClient   *http.Client                // Anonymous client for Invidious, thumbnails and OAuth2 endpoints
+	transport    http.RoundTripper           // Middleware chain shared by both clients
+	timeout      time.Duration
+	logger       *slog.Logger
 	invidiousURL string
-	proxyURL     string
+	apiBaseURL   string
+	thumbnailURL string
 	oauth2Config *oauth2.Config
+	loginTimeout time.Duration
+	tokenStore   TokenStore
+	shortsTab    bool
+	// videoInfoCalls de-duplicates the VideoInfoBatch requests in flight
+	videoInfoCalls singleflight.Group
+	// authMu serializes the setting of the token source; authenticated tells whether
+	// httpClient has one, which Authenticate then reuses
+	authMu        sync.Mutex
+	authenticated bool
 }
 
 // Config holds the configuration for the YouTube client
 type Config struct {
 	InvidiousURL string
+	// ProxyURL routes every request through an http, https or socks5 proxy
 	ProxyURL     string
 	ClientID     string
 	ClientSecret string
-	RedirectURL  string
+	// Deprecated: the loopback login picks its own redirect URL on an ephemeral port.
+	RedirectURL string
+	// LoginTimeout bounds how long the browser login may take (DefaultLoginTimeout if zero)
+	LoginTimeout time.Duration
+	// TokenStore persists the OAuth2 token (a FileTokenStore at DefaultTokenFilePath if nil)
+	TokenStore TokenStore
+	// WriteAccess requests ScopeManage so that write actions are allowed
+	WriteAccess bool
+	// ShortsTab looks up each channel's Shorts tab to mark the Shorts of subscription
+	// feeds, at the cost of one more request per channel. LooksLikeShort is used otherwise.
+	ShortsTab bool
+	// Logger receives the library's own logs; nothing is logged if nil
+	Logger *slog.Logger
+	// Middleware wraps the transport of every request, first entry outermost
+	Middleware []Middleware
 }
 
-// NewClient creates a new YouTube API client with the provided configuration
-func NewClient(config Config) *Client {
+// NewClient creates a new YouTube API client with the provided configuration and options
+func NewClient(config Config, opts ...Option) *Client {
+	options := clientOptions{
+		timeout:          DefaultTimeout,
+		userAgent:        DefaultUserAgent,
+		invidiousURL:     config.InvidiousURL,
+		apiBaseURL:       YoutubeAPIBaseURL,
+		thumbnailBaseURL: DefaultThumbnailBaseURL,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	scopes := []string{ScopeReadOnly}
+	if config.WriteAccess {
+		scopes = []string{ScopeManage}
+	}
+
 	oauth2Config := &oauth2.Config{
 		ClientID:     config.ClientID,
 		ClientSecret: config.ClientSecret,
 		RedirectURL:  config.RedirectURL,
-		Scopes:       []string{"https://www.googleapis.com/auth/youtube.readonly"},
+		Scopes:       scopes,
 		Endpoint:     google.Endpoint,
 	}
 
-	return &Client{
-		httpClient:   &http.Client{},
-		invidiousURL: config.InvidiousURL,
-		proxyURL:     config.ProxyURL,
+	tokenStore := config.TokenStore
+	if tokenStore == nil {
+		tokenStore = &FileTokenStore{Path: DefaultTokenFilePath()}
+	}
+
+	logger := config.Logger
+	if logger == nil {
+		logger = discardLogger()
+	}
+
+	transport := options.transport
+	if transport == nil {
+		transport = http.DefaultTransport
+		if config.ProxyURL != "" {
+			proxied, err := proxyTransport(config.ProxyURL)
+			if err != nil {
+				logger.Warn("Ignoring the proxy.", slog.Any("error", err))
+			} else {
+				transport = proxied
+			}
+		}
+	}
+	if options.userAgent != "" {
+		transport = &userAgentTransport{userAgent: options.userAgent, next: transport}
+	}
+	transport = chainMiddleware(transport, config.Middleware)
+
+	baseClient := &http.Client{Transport: transport, Timeout: options.timeout}
+	client := &Client{
+		baseClient:   baseClient,
+		transport:    transport,
+		timeout:      options.timeout,
+		logger:       logger,
+		invidiousURL: options.invidiousURL,
+		apiBaseURL:   options.apiBaseURL,
+		thumbnailURL: options.thumbnailBaseURL,
 		oauth2Config: oauth2Config,
+		loginTimeout: config.LoginTimeout,
+		tokenStore:   tokenStore,
+		shortsTab:    config.ShortsTab,
 	}
+	client.httpClient.Store(baseClient)
+	if options.tokenSource != nil {
+		client.setTokenSource(options.tokenSource)
+	}
+	return client
 }
 
-// SetHTTPClient sets a custom HTTP client (useful for authentication)
+// SetHTTPClient sets the client of YouTube Data API requests.
+//
+// Deprecated: the client bypasses the transport, timeout and middleware of the
+// options; use WithTokenSource or SetOAuth2Token to authenticate instead.
 func (c *Client) SetHTTPClient(client *http.Client) {
-	c.httpClient = client
+	c.httpClient.Store(client)
 }
 
-// SetOAuth2Token sets the OAuth2 token for authenticated requests
+// SetOAuth2Token authenticates YouTube Data API requests with token, refreshed as needed
 func (c *Client) SetOAuth2Token(token *oauth2.Token) {
-	c.httpClient = c.oauth2Config.Client(context.Background(), token)
+	c.setTokenSource(c.tokenSource(token))
 }
 
-// GetHTTPClient returns the underlying HTTP client
+// GetHTTPClient returns the client of YouTube Data API requests
 func (c *Client) GetHTTPClient() *http.Client {
-	return c.httpClient
+	return c.httpClient.Load()
 }
 
 // GetOAuth2Config returns the OAuth2 configuration
 func (c *Client) GetOAuth2Config() *oauth2.Config {
 	return c.oauth2Config
-}
\ No newline at end of file
+}
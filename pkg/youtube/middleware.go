@@ -0,0 +1,89 @@
+package youtube
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Middleware wraps the transport of every request the client makes: Invidious calls,
+// YouTube Data API calls and OAuth2 token exchanges. It is the hook for logging,
+// metrics and tracing, e.g. otelhttp.NewTransport fits as is.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts a function to http.RoundTripper
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// chainMiddleware wraps base so that the first middleware sees requests first
+func chainMiddleware(base http.RoundTripper, middleware []Middleware) http.RoundTripper {
+	transport := base
+	for i := len(middleware) - 1; i >= 0; i-- {
+		transport = middleware[i](transport)
+	}
+	return transport
+}
+
+// RequestInfo describes a finished request for ObserveMiddleware
+type RequestInfo struct {
+	Method string
+	Host   string
+	// Path leaves out the query, which may carry API keys or tokens
+	Path       string
+	StatusCode int
+	Duration   time.Duration
+	Err        error
+}
+
+// ObserveMiddleware calls observe after every request, for instance to record timing metrics
+func ObserveMiddleware(observe func(RequestInfo)) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+
+			info := RequestInfo{
+				Method:   req.Method,
+				Host:     req.URL.Host,
+				Path:     req.URL.Path,
+				Duration: time.Since(start),
+				Err:      err,
+			}
+			if resp != nil {
+				info.StatusCode = resp.StatusCode
+			}
+			observe(info)
+			return resp, err
+		})
+	}
+}
+
+// LoggingMiddleware logs every request with its status and duration at debug level,
+// and failed ones at warn level
+func LoggingMiddleware(logger *slog.Logger) Middleware {
+	return ObserveMiddleware(func(info RequestInfo) {
+		attrs := []any{
+			slog.String("method", info.Method),
+			slog.String("host", info.Host),
+			slog.String("path", info.Path),
+			slog.Duration("duration", info.Duration),
+		}
+		switch {
+		case info.Err != nil:
+			logger.Warn("HTTP request failed.", append(attrs, slog.Any("error", info.Err))...)
+		case info.StatusCode >= http.StatusBadRequest:
+			logger.Warn("HTTP request returned an error status.", append(attrs, slog.Int("status", info.StatusCode))...)
+		default:
+			logger.Debug("HTTP request done.", append(attrs, slog.Int("status", info.StatusCode))...)
+		}
+	})
+}
+
+// discardLogger is used when no logger is configured
+func discardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
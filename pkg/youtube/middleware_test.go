@@ -0,0 +1,45 @@
+package youtube
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware_WrapsRequestsInOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"videoId":"dQw4w9WgXcQ","title":"Video"}`))
+	}))
+	defer server.Close()
+
+	var calls []string
+	tag := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+	var observed []RequestInfo
+	observe := ObserveMiddleware(func(info RequestInfo) {
+		observed = append(observed, info)
+	})
+
+	client := NewClient(Config{
+		InvidiousURL: server.URL,
+		Middleware:   []Middleware{tag("outer"), tag("inner"), observe},
+	})
+	_, err := client.Search().VideoInfo("dQw4w9WgXcQ")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"outer", "inner"}, calls)
+	require.Len(t, observed, 1)
+	assert.Equal(t, http.MethodGet, observed[0].Method)
+	assert.Equal(t, "/api/v1/videos/dQw4w9WgXcQ", observed[0].Path)
+	assert.Equal(t, http.StatusOK, observed[0].StatusCode)
+	assert.NoError(t, observed[0].Err)
+}
@@ -0,0 +1,170 @@
+package youtube
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+const (
+	// DefaultTimeout bounds every request, so that a hung instance cannot block callers forever
+	DefaultTimeout = 30 * time.Second
+	// DefaultUserAgent is sent with every request unless WithUserAgent overrides it
+	DefaultUserAgent = "ytui-youtube/" + libraryVersion
+	// DefaultThumbnailBaseURL serves the video thumbnails of ThumbnailURL
+	DefaultThumbnailBaseURL = "https://i.ytimg.com"
+)
+
+// Option customizes a client built by NewClient or New
+type Option func(*clientOptions)
+
+// clientOptions collects the options before the HTTP clients are built
+type clientOptions struct {
+	timeout          time.Duration
+	userAgent        string
+	transport        http.RoundTripper
+	invidiousURL     string
+	apiBaseURL       string
+	thumbnailBaseURL string
+	tokenSource      oauth2.TokenSource
+}
+
+// WithTimeout sets the timeout of every request (DefaultTimeout by default, 0 disables it)
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *clientOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithUserAgent sets the User-Agent header of every request
+func WithUserAgent(userAgent string) Option {
+	return func(o *clientOptions) {
+		o.userAgent = userAgent
+	}
+}
+
+// WithTransport sets the transport under the middleware chain, replacing the default
+// one and Config.ProxyURL. Authenticated and anonymous requests both go through it.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(o *clientOptions) {
+		o.transport = transport
+	}
+}
+
+// WithInvidiousURL overrides Config.InvidiousURL
+func WithInvidiousURL(baseURL string) Option {
+	return func(o *clientOptions) {
+		o.invidiousURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithAPIBaseURL overrides the YouTube Data API root, YoutubeAPIBaseURL by default
+func WithAPIBaseURL(baseURL string) Option {
+	return func(o *clientOptions) {
+		o.apiBaseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithThumbnailBaseURL overrides the thumbnail host, DefaultThumbnailBaseURL by default
+func WithThumbnailBaseURL(baseURL string) Option {
+	return func(o *clientOptions) {
+		o.thumbnailBaseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithTokenSource authenticates YouTube Data API requests with tokens from source,
+// bypassing the token store and the browser login
+func WithTokenSource(source oauth2.TokenSource) Option {
+	return func(o *clientOptions) {
+		o.tokenSource = source
+	}
+}
+
+// userAgentTransport sets the User-Agent header of requests that have none
+type userAgentTransport struct {
+	userAgent string
+	next      http.RoundTripper
+}
+
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("User-Agent") != "" {
+		return t.next.RoundTrip(req)
+	}
+	// A RoundTripper must not modify the request it is given
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.userAgent)
+	return t.next.RoundTrip(req)
+}
+
+// proxyTransport returns a copy of the default transport going through proxyURL
+// (http, https or socks5)
+func proxyTransport(proxyURL string) (http.RoundTripper, error) {
+	parsed, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	if parsed.Scheme == "" || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %q: scheme and host are required", proxyURL)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyURL(parsed)
+	return transport, nil
+}
+
+// setTokenSource makes YouTube Data API requests authenticated by source, on top of
+// the same transport as anonymous requests
+func (c *Client) setTokenSource(source oauth2.TokenSource) {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	c.storeTokenSource(source)
+}
+
+// storeTokenSource is setTokenSource with authMu held
+func (c *Client) storeTokenSource(source oauth2.TokenSource) {
+	c.httpClient.Store(&http.Client{
+		Transport: &oauth2.Transport{Source: source, Base: c.transport},
+		Timeout:   c.timeout,
+	})
+	c.authenticated = true
+}
+
+// authenticateOnce sets the token source returned by login, unless one is set already.
+// Concurrent callers wait for the first one instead of logging in again.
+func (c *Client) authenticateOnce(login func() (oauth2.TokenSource, error)) error {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	if c.authenticated {
+		return nil
+	}
+	source, err := login()
+	if err != nil {
+		return err
+	}
+	c.storeTokenSource(source)
+	return nil
+}
+
+// clearTokenSource makes YouTube Data API requests anonymous again
+func (c *Client) clearTokenSource() {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	c.httpClient.Store(c.baseClient)
+	c.authenticated = false
+}
+
+// tokenSource returns a refreshing token source starting from token
+func (c *Client) tokenSource(token *oauth2.Token) oauth2.TokenSource {
+	return c.oauth2Config.TokenSource(c.oauth2Context(context.Background()), token)
+}
+
+// ThumbnailURL returns the URL of a video thumbnail, quality being one of default,
+// mqdefault, hqdefault, sddefault or maxresdefault
+func (c *Client) ThumbnailURL(videoID, quality string) string {
+	return fmt.Sprintf("%s/vi/%s/%s.jpg", c.thumbnailURL, url.PathEscape(videoID), quality)
+}
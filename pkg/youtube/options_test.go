@@ -0,0 +1,77 @@
+package youtube
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func TestOptions_TokenOnlyReachesTheAPI(t *testing.T) {
+	var invidiousAuth, apiAuth, apiAgent string
+	invidious := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		invidiousAuth = r.Header.Get("Authorization")
+		w.Write([]byte(`{"videoId":"dQw4w9WgXcQ","title":"Video"}`))
+	}))
+	defer invidious.Close()
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiAuth = r.Header.Get("Authorization")
+		apiAgent = r.Header.Get("User-Agent")
+		w.Write([]byte(`{"items":[]}`))
+	}))
+	defer api.Close()
+
+	var paths []string
+	observe := ObserveMiddleware(func(info RequestInfo) {
+		paths = append(paths, info.Path)
+	})
+	client := NewClient(Config{Middleware: []Middleware{observe}},
+		WithInvidiousURL(invidious.URL),
+		WithAPIBaseURL(api.URL+"/"),
+		WithUserAgent("test-agent"),
+		WithTokenSource(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "secret", TokenType: "Bearer"})),
+	)
+
+	_, err := client.Search().VideoInfo("dQw4w9WgXcQ")
+	require.NoError(t, err)
+	_, err = client.Playlists().Mine("")
+	require.NoError(t, err)
+
+	assert.Empty(t, invidiousAuth, "the token must not leak to the Invidious instance")
+	assert.Equal(t, "Bearer secret", apiAuth)
+	assert.Equal(t, "test-agent", apiAgent)
+	assert.Equal(t, []string{"/api/v1/videos/dQw4w9WgXcQ", "/playlists"}, paths, "both clients share the middleware")
+}
+
+func TestOptions_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient(Config{InvidiousURL: server.URL}, WithTimeout(50*time.Millisecond))
+	_, err := client.Search().VideoInfo("dQw4w9WgXcQ")
+	assert.Error(t, err)
+}
+
+func TestProxyTransport_RejectsInvalidURL(t *testing.T) {
+	_, err := proxyTransport("127.0.0.1:9050")
+	assert.Error(t, err)
+
+	transport, err := proxyTransport("socks5://127.0.0.1:9050")
+	require.NoError(t, err)
+	proxy, err := transport.(*http.Transport).Proxy(httptest.NewRequest(http.MethodGet, "https://example.com", nil))
+	require.NoError(t, err)
+	assert.Equal(t, "socks5://127.0.0.1:9050", proxy.String())
+}
+
+func TestThumbnailURL(t *testing.T) {
+	client := NewClient(Config{}, WithThumbnailBaseURL("https://thumbs.example.com/"))
+	assert.Equal(t, "https://thumbs.example.com/vi/dQw4w9WgXcQ/hqdefault.jpg", client.ThumbnailURL("dQw4w9WgXcQ", "hqdefault"))
+}
@@ -0,0 +1,143 @@
+package youtube
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// PlaylistsService handles the authenticated user's playlists
+type PlaylistsService struct {
+	client *Client
+}
+
+// NewPlaylistsService creates a new playlists service
+func (c *Client) Playlists() *PlaylistsService {
+	return &PlaylistsService{client: c}
+}
+
+// AddVideo appends a video to a playlist and returns the playlist item ID.
+// Requires a client created with WriteAccess.
+func (s *PlaylistsService) AddVideo(playlistID, videoID string) (string, error) {
+	payload := map[string]interface{}{
+		"snippet": map[string]interface{}{
+			"playlistId": playlistID,
+			"resourceId": map[string]string{
+				"kind":    "youtube#video",
+				"videoId": videoID,
+			},
+		},
+	}
+
+	var item struct {
+		ID string `json:"id"`
+	}
+	fullURL := fmt.Sprintf("%s/playlistItems?part=snippet", s.client.apiBaseURL)
+	if err := s.client.doAPI(http.MethodPost, fullURL, payload, &item); err != nil {
+		return "", fmt.Errorf("failed to add video %s to playlist %s: %w", videoID, playlistID, err)
+	}
+	return item.ID, nil
+}
+
+// playlistsResponse is the YouTube Data API playlists.list response
+type playlistsResponse struct {
+	NextPageToken string `json:"nextPageToken"`
+	Items         []struct {
+		ID      string `json:"id"`
+		Snippet struct {
+			Title        string `json:"title"`
+			Description  string `json:"description"`
+			ChannelTitle string `json:"channelTitle"`
+		} `json:"snippet"`
+		ContentDetails struct {
+			ItemCount int64 `json:"itemCount"`
+		} `json:"contentDetails"`
+	} `json:"items"`
+}
+
+// playlistItemsResponse is the YouTube Data API playlistItems.list response
+type playlistItemsResponse struct {
+	NextPageToken string `json:"nextPageToken"`
+	Items         []struct {
+		Snippet struct {
+			Title                  string        `json:"title"`
+			Description            string        `json:"description"`
+			VideoOwnerChannelTitle string        `json:"videoOwnerChannelTitle"`
+			VideoOwnerChannelID    string        `json:"videoOwnerChannelId"`
+			Thumbnails             apiThumbnails `json:"thumbnails"`
+		} `json:"snippet"`
+		ContentDetails struct {
+			VideoID          string `json:"videoId"`
+			VideoPublishedAt string `json:"videoPublishedAt"`
+		} `json:"contentDetails"`
+	} `json:"items"`
+}
+
+// Mine lists the authenticated user's playlists. Pass the previous page's NextPageToken
+// to continue, an empty token starts from the first page.
+func (s *PlaylistsService) Mine(pageToken string) (PlaylistPage, error) {
+	params := url.Values{}
+	params.Set("part", "snippet,contentDetails")
+	params.Set("mine", "true")
+	params.Set("maxResults", "50")
+	if pageToken != "" {
+		params.Set("pageToken", pageToken)
+	}
+
+	var response playlistsResponse
+	if err := s.client.doAPI(http.MethodGet, fmt.Sprintf("%s/playlists?%s", s.client.apiBaseURL, params.Encode()), nil, &response); err != nil {
+		return PlaylistPage{}, fmt.Errorf("failed to list playlists: %w", err)
+	}
+
+	page := PlaylistPage{NextPageToken: response.NextPageToken}
+	for _, item := range response.Items {
+		page.Items = append(page.Items, Playlist{
+			ID:          item.ID,
+			Title:       item.Snippet.Title,
+			Description: item.Snippet.Description,
+			ItemCount:   item.ContentDetails.ItemCount,
+			Author:      item.Snippet.ChannelTitle,
+		})
+	}
+	return page, nil
+}
+
+// Items lists one page of the videos of a playlist the authenticated user can read
+func (s *PlaylistsService) Items(playlistID, pageToken string) (VideoPage, error) {
+	params := url.Values{}
+	params.Set("part", "snippet,contentDetails")
+	params.Set("playlistId", playlistID)
+	params.Set("maxResults", "50")
+	if pageToken != "" {
+		params.Set("pageToken", pageToken)
+	}
+
+	var response playlistItemsResponse
+	if err := s.client.doAPI(http.MethodGet, fmt.Sprintf("%s/playlistItems?%s", s.client.apiBaseURL, params.Encode()), nil, &response); err != nil {
+		return VideoPage{}, fmt.Errorf("failed to list items of playlist %s: %w", playlistID, err)
+	}
+
+	page := VideoPage{NextPageToken: response.NextPageToken}
+	for _, item := range response.Items {
+		// Deleted and private videos stay in playlists without an owner
+		if item.Snippet.VideoOwnerChannelID == "" {
+			continue
+		}
+		video := SearchResultItem{
+			Type:            "video",
+			Title:           item.Snippet.Title,
+			VideoID:         item.ContentDetails.VideoID,
+			Author:          item.Snippet.VideoOwnerChannelTitle,
+			AuthorID:        item.Snippet.VideoOwnerChannelID,
+			Description:     item.Snippet.Description,
+			VideoThumbnails: item.Snippet.Thumbnails.toVideoThumbnails(),
+		}
+		if published, err := time.Parse(time.RFC3339, item.ContentDetails.VideoPublishedAt); err == nil {
+			video.Published = published.Unix()
+			video.PublishedText = published.Format("2006-01-02")
+		}
+		page.Items = append(page.Items, video)
+	}
+	return page, nil
+}
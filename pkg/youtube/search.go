@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/url"
 	"sort"
+	"strconv"
 )
 
 // SearchService handles video search operations
@@ -37,23 +38,83 @@ func (s *SearchService) VideoInfo(videoID string) (SearchResultItem, error) {
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusOK {
+		return SearchResultItem{}, fmt.Errorf("received non-200 response: %d %s", resp.StatusCode, resp.Status)
+	}
+
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return SearchResultItem{}, fmt.Errorf("error reading response body: %v", err)
 	}
 
-	var videoInfo VideoSnippet
+	// The Invidious video object shares its field names with search results
+	var videoInfo SearchResultItem
 	if err := json.Unmarshal(body, &videoInfo); err != nil {
 		return SearchResultItem{}, fmt.Errorf("error parsing JSON: %v", err)
 	}
+	videoInfo.VideoID = videoID
+	videoInfo.Type = "video"
+
+	return videoInfo, nil
+}
+
+// Playlist retrieves a public playlist and one page of its videos. Pages are numbered
+// from 1; pageToken is empty for the first page and NextPageToken is empty on the last.
+func (s *SearchService) Playlist(playlistID, pageToken string) (Playlist, VideoPage, error) {
+	page := 1
+	if pageToken != "" {
+		var err error
+		if page, err = strconv.Atoi(pageToken); err != nil {
+			return Playlist{}, VideoPage{}, fmt.Errorf("invalid page token %q", pageToken)
+		}
+	}
+	fullURL := fmt.Sprintf("%s/api/v1/playlists/%s?page=%d", s.client.invidiousURL, url.PathEscape(playlistID), page)
+
+	resp, err := s.makeRequest(fullURL)
+	if err != nil {
+		return Playlist{}, VideoPage{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Playlist{}, VideoPage{}, fmt.Errorf("received non-200 response: %d %s", resp.StatusCode, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Playlist{}, VideoPage{}, fmt.Errorf("error reading response body: %v", err)
+	}
+
+	var response struct {
+		PlaylistID  string `json:"playlistId"`
+		Title       string `json:"title"`
+		Description string `json:"description"`
+		Author      string `json:"author"`
+		VideoCount  int64  `json:"videoCount"`
+		Videos      []struct {
+			SearchResultItem
+			Index int64 `json:"index"`
+		} `json:"videos"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return Playlist{}, VideoPage{}, fmt.Errorf("error parsing JSON: %v", err)
+	}
 
-	return SearchResultItem{
-		VideoID:       videoID,
-		Title:         videoInfo.Title,
-		Description:   videoInfo.Description,
-		Author:        videoInfo.ChannelTitle,
-		PublishedText: videoInfo.PublishedAt,
-	}, nil
+	playlist := Playlist{
+		ID:          response.PlaylistID,
+		Title:       response.Title,
+		Description: response.Description,
+		ItemCount:   response.VideoCount,
+		Author:      response.Author,
+	}
+	var videoPage VideoPage
+	for _, video := range response.Videos {
+		videoPage.Items = append(videoPage.Items, video.SearchResultItem)
+	}
+	if n := len(response.Videos); n > 0 && response.Videos[n-1].Index+1 < response.VideoCount {
+		videoPage.NextPageToken = strconv.Itoa(page + 1)
+	}
+	return playlist, videoPage, nil
 }
 
 // ChannelInfo retrieves information about a specific channel
@@ -139,17 +200,9 @@ func (s *SearchService) searchVideos(options SearchOptions) ([]SearchResultItem,
 	return aggregatedResults, nil
 }
 
+// makeRequest sends an anonymous request: OAuth2 tokens must never reach the Invidious instance
 func (s *SearchService) makeRequest(fullURL string) (*http.Response, error) {
-	if s.client.proxyURL != "" {
-		return s.makeRequestWithProxy(fullURL)
-	}
-	return s.client.httpClient.Get(fullURL)
-}
-
-func (s *SearchService) makeRequestWithProxy(fullURL string) (*http.Response, error) {
-	// Proxy implementation would go here
-	// For now, just use regular client
-	return s.client.httpClient.Get(fullURL)
+	return s.client.baseClient.Get(fullURL)
 }
 
 func (s *SearchService) processResponse(resp *http.Response) ([]SearchResultItem, error) {
@@ -166,6 +219,7 @@ func (s *SearchService) processResponse(resp *http.Response) ([]SearchResultItem
 	if err := json.Unmarshal(body, &searchResponse); err != nil {
 		return nil, fmt.Errorf("error parsing JSON: %v", err)
 	}
+	markShorts(searchResponse)
 
 	// Sort by Published date in descending order
 	sort.Slice(searchResponse, func(i, j int) bool {
@@ -194,6 +248,7 @@ func (s *SearchService) processSubscribedVideoResponse(resp *http.Response) ([]S
 	if err := json.Unmarshal(temp["videos"], &searchResponse); err != nil {
 		return nil, fmt.Errorf("error parsing videos JSON: %v", err)
 	}
+	markShorts(searchResponse)
 
 	// Sort by Published date in descending order
 	sort.Slice(searchResponse, func(i, j int) bool {
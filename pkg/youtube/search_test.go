@@ -0,0 +1,63 @@
+package youtube
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVideoInfo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/videos/dQw4w9WgXcQ" {
+			http.Error(w, `{"error":"Video unavailable"}`, http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"title":"Never Gonna Give You Up","author":"Rick Astley","authorId":"UCuAXFkgsw1L7xaCfnd5JJOw",` +
+			`"lengthSeconds":212,"description":"The official video","publishedText":"15 years ago"}`))
+	}))
+	defer server.Close()
+
+	search := NewClient(Config{InvidiousURL: server.URL}).Search()
+	video, err := search.VideoInfo("dQw4w9WgXcQ")
+	require.NoError(t, err)
+	assert.Equal(t, "dQw4w9WgXcQ", video.VideoID)
+	assert.Equal(t, "Rick Astley", video.Author)
+	assert.Equal(t, "UCuAXFkgsw1L7xaCfnd5JJOw", video.AuthorID)
+	assert.Equal(t, int32(212), video.LengthSeconds)
+
+	_, err = search.VideoInfo("xxxxxxxxxxx")
+	require.Error(t, err)
+}
+
+func TestPlaylist_Pages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/playlists/PL123", r.URL.Path)
+		switch r.URL.Query().Get("page") {
+		case "1":
+			w.Write([]byte(`{"playlistId":"PL123","title":"Mix","author":"Someone","videoCount":3,"videos":[` +
+				`{"title":"One","videoId":"aaaaaaaaaaa","index":0},{"title":"Two","videoId":"bbbbbbbbbbb","index":1}]}`))
+		case "2":
+			w.Write([]byte(`{"playlistId":"PL123","title":"Mix","author":"Someone","videoCount":3,"videos":[` +
+				`{"title":"Three","videoId":"ccccccccccc","index":2}]}`))
+		default:
+			t.Errorf("unexpected page %q", r.URL.Query().Get("page"))
+		}
+	}))
+	defer server.Close()
+
+	search := NewClient(Config{InvidiousURL: server.URL}).Search()
+	playlist, page, err := search.Playlist("PL123", "")
+	require.NoError(t, err)
+	assert.Equal(t, Playlist{ID: "PL123", Title: "Mix", Author: "Someone", ItemCount: 3}, playlist)
+	require.Len(t, page.Items, 2)
+	assert.Equal(t, "aaaaaaaaaaa", page.Items[0].VideoID)
+	assert.Equal(t, "2", page.NextPageToken)
+
+	_, page, err = search.Playlist("PL123", page.NextPageToken)
+	require.NoError(t, err)
+	require.Len(t, page.Items, 1)
+	assert.Empty(t, page.NextPageToken)
+}
@@ -0,0 +1,113 @@
+package youtube
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+)
+
+// MaxShortLength is the longest a Short can be, in seconds
+const MaxShortLength = 180
+
+// ChannelShorts retrieves the videos of a channel's Shorts tab
+func (s *SearchService) ChannelShorts(channelID string) ([]SearchResultItem, error) {
+	baseURL := fmt.Sprintf("%s/api/v1/channels/%s/shorts", s.client.invidiousURL, channelID)
+
+	resp, err := s.makeRequest(baseURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	shorts, err := s.processSubscribedVideoResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+	for i := range shorts {
+		shorts[i].IsShort = true
+	}
+	return shorts, nil
+}
+
+// LooksLikeShort guesses whether a video is a Short when no Shorts tab tells it: a
+// #shorts tag, or a short duration with a portrait thumbnail. Short landscape videos,
+// such as trailers and clips, are not Shorts.
+func LooksLikeShort(video SearchResultItem) bool {
+	if video.LiveNow || video.IsUpcoming {
+		return false
+	}
+	lowerText := strings.ToLower(video.Title + " " + video.Description)
+	if strings.Contains(lowerText, "#shorts") {
+		return true
+	}
+	if video.LengthSeconds <= 0 || video.LengthSeconds > MaxShortLength {
+		return false
+	}
+	for _, thumbnail := range video.VideoThumbnails {
+		if thumbnail.Height > thumbnail.Width {
+			return true
+		}
+	}
+	return false
+}
+
+// markShorts sets IsShort on the videos matching the heuristic
+func markShorts(videos []SearchResultItem) {
+	for i := range videos {
+		if !videos[i].IsShort {
+			videos[i].IsShort = LooksLikeShort(videos[i])
+		}
+	}
+}
+
+// markShortIDs sets IsShort on the videos listed in a Shorts tab
+func markShortIDs(videos, shorts []SearchResultItem) {
+	shortIDs := make(map[string]bool, len(shorts))
+	for _, short := range shorts {
+		shortIDs[short.VideoID] = true
+	}
+	for i := range videos {
+		if shortIDs[videos[i].VideoID] {
+			videos[i].IsShort = true
+		}
+	}
+}
+
+// GetShortsFromChannels retrieves the Shorts of the specified channel IDs, newest first.
+// Channels that fail are logged and skipped; it fails only when they all do.
+func (s *SubscriptionsService) GetShortsFromChannels(channelIDs []string) ([]SearchResultItem, error) {
+	var (
+		shorts []SearchResultItem
+		errs   []error
+	)
+	for _, channelID := range channelIDs {
+		channelShorts, err := s.client.Search().ChannelShorts(channelID)
+		if err != nil {
+			// One failing channel doesn't empty the whole view
+			s.client.logger.Warn("Failed to fetch the Shorts of a channel, skipping it.", slog.String("channel", channelID), slog.Any("error", err))
+			errs = append(errs, fmt.Errorf("failed to fetch shorts for channel %s: %w", channelID, err))
+			continue
+		}
+		shorts = append(shorts, channelShorts...)
+	}
+
+	if len(errs) > 0 && len(errs) == len(channelIDs) {
+		return nil, errors.Join(errs...)
+	}
+
+	sort.Slice(shorts, func(i, j int) bool {
+		return shorts[i].Published > shorts[j].Published
+	})
+	return shorts, nil
+}
+
+// GetAllShorts retrieves the Shorts of all subscribed channels
+func (s *SubscriptionsService) GetAllShorts() ([]SearchResultItem, error) {
+	channelIDs, err := s.GetChannelIDs()
+	if err != nil {
+		return nil, err
+	}
+	return s.GetShortsFromChannels(channelIDs)
+}
@@ -0,0 +1,79 @@
+package youtube
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLooksLikeShort(t *testing.T) {
+	portrait := []VideoThumbnail{{Quality: "maxres", Width: 720, Height: 1280}}
+	landscape := []VideoThumbnail{{Quality: "maxres", Width: 1280, Height: 720}}
+
+	tests := []struct {
+		name  string
+		video SearchResultItem
+		want  bool
+	}{
+		{"portrait under a minute", SearchResultItem{LengthSeconds: 45, VideoThumbnails: portrait}, true},
+		{"landscape trailer", SearchResultItem{Title: "Official Trailer", LengthSeconds: 45, VideoThumbnails: landscape}, false},
+		{"short without thumbnails", SearchResultItem{LengthSeconds: 45}, false},
+		{"tagged", SearchResultItem{Title: "Wait for it #Shorts", LengthSeconds: 600}, true},
+		{"portrait and short", SearchResultItem{LengthSeconds: 150, VideoThumbnails: portrait}, true},
+		{"landscape and short", SearchResultItem{LengthSeconds: 150, VideoThumbnails: landscape}, false},
+		{"portrait but long", SearchResultItem{LengthSeconds: 900, VideoThumbnails: portrait}, false},
+		{"unknown length", SearchResultItem{}, false},
+		{"live", SearchResultItem{LiveNow: true, LengthSeconds: 30}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, LooksLikeShort(tt.video))
+		})
+	}
+}
+
+func TestGetVideosFromChannels_MarksShortsFromTab(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/channels/UC1/videos":
+			w.Write([]byte(`{"videos":[` +
+				`{"videoId":"aaaaaaaaaaa","title":"Long one","lengthSeconds":1200,"published":2},` +
+				`{"videoId":"bbbbbbbbbbb","title":"Vertical clip","lengthSeconds":170,"published":1}]}`))
+		case "/api/v1/channels/UC1/shorts":
+			w.Write([]byte(`{"videos":[{"videoId":"bbbbbbbbbbb","title":"Vertical clip"}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	videos, err := NewClient(Config{InvidiousURL: server.URL, ShortsTab: true}).Subscriptions().GetVideosFromChannels([]string{"UC1"})
+	require.NoError(t, err)
+	require.Len(t, videos, 2)
+	assert.False(t, videos[0].IsShort)
+	assert.True(t, videos[1].IsShort, "the Shorts tab catches what the heuristic misses")
+}
+
+func TestGetShortsFromChannels_SkipsFailingChannels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/channels/UC1/shorts":
+			w.Write([]byte(`{"videos":[{"videoId":"aaaaaaaaaaa","title":"Vertical clip"}]}`))
+		default:
+			http.Error(w, "channel unavailable", http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	subscriptions := NewClient(Config{InvidiousURL: server.URL}).Subscriptions()
+	shorts, err := subscriptions.GetShortsFromChannels([]string{"UC1", "UC2"})
+	require.NoError(t, err)
+	require.Len(t, shorts, 1)
+	assert.True(t, shorts[0].IsShort)
+
+	_, err = subscriptions.GetShortsFromChannels([]string{"UC2"})
+	assert.Error(t, err, "every channel failed")
+}
@@ -9,6 +9,7 @@ import (
 	"sort"
 )
 
+// YoutubeSubscriptionsURL is the subscriptions endpoint under the default YoutubeAPIBaseURL
 const YoutubeSubscriptionsURL = "https://www.googleapis.com/youtube/v3/subscriptions"
 
 // SubscriptionsService handles subscription-related operations
@@ -28,9 +29,9 @@ func (s *SubscriptionsService) GetChannelIDs() ([]string, error) {
 	params.Set("mine", "true")
 	params.Set("maxResults", "50")
 
-	fullURL := fmt.Sprintf("%s?%s", YoutubeSubscriptionsURL, params.Encode())
+	fullURL := fmt.Sprintf("%s/subscriptions?%s", s.client.apiBaseURL, params.Encode())
 	
-	resp, err := s.client.httpClient.Get(fullURL)
+	resp, err := s.client.httpClient.Load().Get(fullURL)
 	if err != nil {
 		return nil, fmt.Errorf("error fetching subscriptions from YouTube API: %v", err)
 	}
@@ -84,6 +85,13 @@ func (s *SubscriptionsService) GetVideosFromChannels(channelIDs []string) ([]Sea
 			return nil, fmt.Errorf("failed to fetch videos for channel %s: %v", channelID, err)
 		}
 
+		if s.client.shortsTab {
+			// Instances without a Shorts tab leave the heuristic alone
+			if shorts, err := searchService.ChannelShorts(channelID); err == nil {
+				markShortIDs(videosResponse, shorts)
+			}
+		}
+
 		aggregatedResponse = append(aggregatedResponse, videosResponse...)
 	}
 
@@ -95,4 +103,53 @@ func (s *SubscriptionsService) GetVideosFromChannels(channelIDs []string) ([]Sea
 	}
 
 	return aggregatedResponse, nil
-}
\ No newline at end of file
+}
+
+// Subscribe subscribes the authenticated user to a channel and returns the subscription ID.
+// Requires a client created with WriteAccess.
+func (s *SubscriptionsService) Subscribe(channelID string) (string, error) {
+	payload := map[string]interface{}{
+		"snippet": map[string]interface{}{
+			"resourceId": map[string]string{
+				"kind":      "youtube#channel",
+				"channelId": channelID,
+			},
+		},
+	}
+
+	var subscription struct {
+		ID string `json:"id"`
+	}
+	fullURL := fmt.Sprintf("%s/subscriptions?part=snippet", s.client.apiBaseURL)
+	if err := s.client.doAPI(http.MethodPost, fullURL, payload, &subscription); err != nil {
+		return "", fmt.Errorf("failed to subscribe to channel %s: %w", channelID, err)
+	}
+	return subscription.ID, nil
+}
+
+// Unsubscribe removes the authenticated user's subscription to a channel.
+// Requires a client created with WriteAccess.
+func (s *SubscriptionsService) Unsubscribe(channelID string) error {
+	params := url.Values{}
+	params.Set("part", "id")
+	params.Set("mine", "true")
+	params.Set("forChannelId", channelID)
+
+	var found struct {
+		Items []struct {
+			ID string `json:"id"`
+		} `json:"items"`
+	}
+	if err := s.client.doAPI(http.MethodGet, fmt.Sprintf("%s/subscriptions?%s", s.client.apiBaseURL, params.Encode()), nil, &found); err != nil {
+		return fmt.Errorf("failed to look up subscription to channel %s: %w", channelID, err)
+	}
+	if len(found.Items) == 0 {
+		return fmt.Errorf("not subscribed to channel %s", channelID)
+	}
+
+	deleteURL := fmt.Sprintf("%s/subscriptions?id=%s", s.client.apiBaseURL, url.QueryEscape(found.Items[0].ID))
+	if err := s.client.doAPI(http.MethodDelete, deleteURL, nil, nil); err != nil {
+		return fmt.Errorf("failed to unsubscribe from channel %s: %w", channelID, err)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package youtube
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/oauth2"
+)
+
+// ErrNoToken is returned by a TokenStore that holds no token yet
+var ErrNoToken = errors.New("no stored OAuth2 token")
+
+// TokenStore persists the OAuth2 token between runs
+type TokenStore interface {
+	LoadToken() (*oauth2.Token, error)
+	SaveToken(token *oauth2.Token) error
+	DeleteToken() error
+}
+
+// FileTokenStore keeps the token as JSON in a file readable only by the current user
+type FileTokenStore struct {
+	Path string
+}
+
+// DefaultTokenFilePath returns the historical token location, ~/.config/ytui/credentials.json
+func DefaultTokenFilePath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".config", "ytui", "credentials.json")
+}
+
+// LoadToken reads the token file
+func (s *FileTokenStore) LoadToken() (*oauth2.Token, error) {
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNoToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := UnmarshalToken(data)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing token file: %v", err)
+	}
+	return token, nil
+}
+
+// SaveToken writes the token file with 0600 permissions
+func (s *FileTokenStore) SaveToken(token *oauth2.Token) error {
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0o700); err != nil {
+		return err
+	}
+
+	data, err := MarshalToken(token)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.Path), ".credentials-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	// CreateTemp already uses 0600; the file is renamed over the old one so
+	// a previously world-readable token never keeps its permissions
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.Path)
+}
+
+// DeleteToken removes the token file
+func (s *FileTokenStore) DeleteToken() error {
+	if err := os.Remove(s.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// storedToken is the persisted form of a token: the oauth2.Token fields plus the granted scope
+type storedToken struct {
+	*oauth2.Token
+	Scope string `json:"scope,omitempty"`
+}
+
+// MarshalToken encodes a token together with the scope that was granted for it
+func MarshalToken(token *oauth2.Token) ([]byte, error) {
+	return json.Marshal(storedToken{Token: token, Scope: grantedScope(token)})
+}
+
+// UnmarshalToken decodes a token written by MarshalToken or a plain oauth2.Token JSON
+func UnmarshalToken(data []byte) (*oauth2.Token, error) {
+	stored := storedToken{Token: &oauth2.Token{}}
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, err
+	}
+	token := stored.Token
+	if stored.Scope != "" {
+		token = token.WithExtra(map[string]interface{}{"scope": stored.Scope})
+	}
+	return token, nil
+}
+
+// grantedScope returns the space separated scopes the token was issued for, if known
+func grantedScope(token *oauth2.Token) string {
+	if token == nil {
+		return ""
+	}
+	scope, _ := token.Extra("scope").(string)
+	return scope
+}
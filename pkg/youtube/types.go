@@ -1,5 +1,7 @@
 package youtube
 
+import "time"
+
 // SearchResultItem represents a single video search result
 type SearchResultItem struct {
 	Type            string           `json:"type"`
@@ -16,6 +18,44 @@ type SearchResultItem struct {
 	PublishedText   string           `json:"publishedText"`
 	LengthSeconds   int32            `json:"lengthSeconds"`
 	ViewedDate      int64            `json:"vieweddate"`
+	LiveNow         bool             `json:"liveNow"`
+	IsUpcoming      bool             `json:"isUpcoming"`
+	// PremiereTimestamp is the Unix time an upcoming stream or premiere starts at
+	PremiereTimestamp int64 `json:"premiereTimestamp"`
+	// IsShort is set for Shorts, from a channel's Shorts tab or LooksLikeShort
+	IsShort bool `json:"isShort"`
+}
+
+// Premiere returns when an upcoming video starts, false when it is not upcoming or
+// its start is unknown
+func (v SearchResultItem) Premiere() (time.Time, bool) {
+	if !v.IsUpcoming || v.PremiereTimestamp == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(v.PremiereTimestamp, 0), true
+}
+
+// PublishedAt returns the publication time, zero when unknown
+func (v SearchResultItem) PublishedAt() time.Time {
+	return unixTime(v.Published)
+}
+
+// Length returns the duration of the video, zero for live streams and unknown lengths
+func (v SearchResultItem) Length() time.Duration {
+	return time.Duration(v.LengthSeconds) * time.Second
+}
+
+// ViewedAt returns when the video was added to the watch history, zero if never
+func (v SearchResultItem) ViewedAt() time.Time {
+	return unixTime(v.ViewedDate)
+}
+
+// unixTime converts a Unix timestamp, keeping 0 as the zero time
+func unixTime(seconds int64) time.Time {
+	if seconds == 0 {
+		return time.Time{}
+	}
+	return time.Unix(seconds, 0)
 }
 
 // VideoThumbnail represents a video thumbnail
@@ -68,4 +108,41 @@ func DefaultSearchOptions() SearchOptions {
 		Region:   "US",
 		Type:     "video",
 	}
-}
\ No newline at end of file
+}
+
+// Playlist represents a playlist of the authenticated user
+type Playlist struct {
+	ID          string
+	Title       string
+	Description string
+	ItemCount   int64
+	Author      string
+}
+
+// PlaylistPage is one page of playlists
+type PlaylistPage struct {
+	Items         []Playlist
+	NextPageToken string
+}
+
+// VideoPage is one page of videos from a paged YouTube Data API listing
+type VideoPage struct {
+	Items         []SearchResultItem
+	NextPageToken string
+}
+
+// apiThumbnails is the thumbnails object of YouTube Data API snippets
+type apiThumbnails map[string]struct {
+	URL    string `json:"url"`
+	Width  int32  `json:"width"`
+	Height int32  `json:"height"`
+}
+
+// toVideoThumbnails converts YouTube Data API thumbnails to the Invidious layout
+func (t apiThumbnails) toVideoThumbnails() []VideoThumbnail {
+	var thumbnails []VideoThumbnail
+	for quality, thumb := range t {
+		thumbnails = append(thumbnails, VideoThumbnail{Quality: quality, URL: thumb.URL, Width: thumb.Width, Height: thumb.Height})
+	}
+	return thumbnails
+}
@@ -0,0 +1,254 @@
+package youtube
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RefKind is what a YouTube URL points to
+type RefKind int
+
+const (
+	RefUnknown RefKind = iota
+	RefVideo
+	RefPlaylist
+	RefChannel
+	// RefHandle, RefCustomURL and RefUser name a channel without its ID, see
+	// SearchService.Resolve
+	RefHandle
+	RefCustomURL
+	// RefUser is a legacy /user/ URL, a namespace apart from the /c/ custom URLs
+	RefUser
+)
+
+func (k RefKind) String() string {
+	switch k {
+	case RefVideo:
+		return "video"
+	case RefPlaylist:
+		return "playlist"
+	case RefChannel:
+		return "channel"
+	case RefHandle:
+		return "handle"
+	case RefCustomURL:
+		return "custom URL"
+	case RefUser:
+		return "user"
+	}
+	return "unknown"
+}
+
+// Ref is a typed reference parsed from a YouTube, Invidious or Piped URL
+type Ref struct {
+	Kind      RefKind
+	VideoID   string
+	ChannelID string
+	// PlaylistID is set for playlists and for videos opened from a playlist
+	PlaylistID string
+	// Handle is the channel handle without the leading '@'
+	Handle string
+	// CustomName is the name of a legacy /c/ or /user/ channel URL
+	CustomName string
+	// Start is the timestamp the video should start at
+	Start time.Duration
+}
+
+// ErrUnsupportedURL is returned for URLs that do not point to a video, playlist or channel
+var ErrUnsupportedURL = errors.New("unsupported YouTube URL")
+
+var (
+	videoIDPattern    = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+	channelIDPattern  = regexp.MustCompile(`^UC[A-Za-z0-9_-]{22}$`)
+	playlistIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{2,}$`)
+	handlePattern     = regexp.MustCompile(`^[A-Za-z0-9._-]{3,30}$`)
+	timestampPattern  = regexp.MustCompile(`^(?:(\d+)h)?(?:(\d+)m)?(?:(\d+)s?)?$`)
+)
+
+// IsVideoID reports whether s has the shape of a video ID
+func IsVideoID(s string) bool {
+	return videoIDPattern.MatchString(s)
+}
+
+// ParseURL parses the URL forms of YouTube and of Invidious or Piped instances: watch,
+// youtu.be, shorts, embed, live, playlist, /channel/UC…, /@handle, /c/ and /user/, with
+// t= or start= timestamps. The scheme may be omitted. Handles and custom URLs must be
+// resolved to get a channel ID.
+func ParseURL(raw string) (Ref, error) {
+	raw = strings.TrimSpace(raw)
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Host == "" {
+		return Ref{}, fmt.Errorf("%w: %q", ErrUnsupportedURL, raw)
+	}
+
+	query := parsed.Query()
+	ref := Ref{PlaylistID: query.Get("list")}
+	if !validPlaylistID(ref.PlaylistID) {
+		ref.PlaylistID = ""
+	}
+	ref.Start = parseStart(query, parsed.Fragment)
+
+	host := strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+	segments := strings.FieldsFunc(parsed.Path, func(r rune) bool { return r == '/' })
+
+	switch {
+	case host == "youtu.be":
+		if len(segments) > 0 && IsVideoID(segments[0]) {
+			ref.Kind, ref.VideoID = RefVideo, segments[0]
+		}
+	case len(segments) == 0:
+	case segments[0] == "watch":
+		if IsVideoID(query.Get("v")) {
+			ref.Kind, ref.VideoID = RefVideo, query.Get("v")
+		} else if ref.PlaylistID != "" {
+			ref.Kind = RefPlaylist
+		}
+	case segments[0] == "playlist":
+		if ref.PlaylistID != "" {
+			ref.Kind = RefPlaylist
+		}
+	case len(segments) >= 2 && isVideoPath(segments[0]):
+		if IsVideoID(segments[1]) {
+			ref.Kind, ref.VideoID = RefVideo, segments[1]
+		}
+	case segments[0] == "channel" && len(segments) >= 2:
+		if channelIDPattern.MatchString(segments[1]) {
+			ref.Kind, ref.ChannelID = RefChannel, segments[1]
+		}
+	case strings.HasPrefix(segments[0], "@"):
+		if handle := strings.TrimPrefix(segments[0], "@"); handlePattern.MatchString(handle) {
+			ref.Kind, ref.Handle = RefHandle, handle
+		}
+	case segments[0] == "c" && len(segments) >= 2:
+		ref.Kind, ref.CustomName = RefCustomURL, segments[1]
+	case segments[0] == "user" && len(segments) >= 2:
+		ref.Kind, ref.CustomName = RefUser, segments[1]
+	}
+
+	if ref.Kind == RefUnknown {
+		return Ref{}, fmt.Errorf("%w: %q", ErrUnsupportedURL, raw)
+	}
+	return ref, nil
+}
+
+// isVideoPath reports whether a first path segment is followed by a video ID
+func isVideoPath(segment string) bool {
+	switch segment {
+	case "shorts", "embed", "live", "v", "e":
+		return true
+	}
+	return false
+}
+
+func validPlaylistID(id string) bool {
+	return id != "" && playlistIDPattern.MatchString(id)
+}
+
+// parseStart reads t= or start= from the query, or t= from the fragment
+func parseStart(query url.Values, fragment string) time.Duration {
+	value := query.Get("t")
+	if value == "" {
+		value = query.Get("start")
+	}
+	if value == "" {
+		if fragmentQuery, err := url.ParseQuery(fragment); err == nil {
+			value = fragmentQuery.Get("t")
+		}
+	}
+	return parseTimestamp(value)
+}
+
+// parseTimestamp accepts plain seconds or the 1h2m3s form
+func parseTimestamp(value string) time.Duration {
+	matches := timestampPattern.FindStringSubmatch(value)
+	if value == "" || matches == nil {
+		return 0
+	}
+	var total time.Duration
+	units := []time.Duration{time.Hour, time.Minute, time.Second}
+	for i, unit := range units {
+		if matches[i+1] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(matches[i+1])
+		if err != nil {
+			return 0
+		}
+		total += time.Duration(n) * unit
+	}
+	return total
+}
+
+// URL returns the canonical youtube.com URL of the reference
+func (r Ref) URL() string {
+	switch r.Kind {
+	case RefVideo:
+		values := url.Values{"v": {r.VideoID}}
+		if r.PlaylistID != "" {
+			values.Set("list", r.PlaylistID)
+		}
+		if r.Start > 0 {
+			values.Set("t", strconv.Itoa(int(r.Start.Seconds()))+"s")
+		}
+		return "https://www.youtube.com/watch?" + values.Encode()
+	case RefPlaylist:
+		return "https://www.youtube.com/playlist?list=" + url.QueryEscape(r.PlaylistID)
+	case RefChannel:
+		return "https://www.youtube.com/channel/" + r.ChannelID
+	case RefHandle:
+		return "https://www.youtube.com/@" + r.Handle
+	case RefCustomURL:
+		return "https://www.youtube.com/c/" + url.PathEscape(r.CustomName)
+	case RefUser:
+		return "https://www.youtube.com/user/" + url.PathEscape(r.CustomName)
+	}
+	return ""
+}
+
+// Resolve turns a handle or custom URL reference into a channel reference using the
+// Invidious /api/v1/resolveurl endpoint. Other references are returned unchanged.
+func (s *SearchService) Resolve(ref Ref) (Ref, error) {
+	if ref.Kind != RefHandle && ref.Kind != RefCustomURL && ref.Kind != RefUser {
+		return ref, nil
+	}
+
+	fullURL := fmt.Sprintf("%s/api/v1/resolveurl?url=%s", s.client.invidiousURL, url.QueryEscape(ref.URL()))
+	resp, err := s.makeRequest(fullURL)
+	if err != nil {
+		return ref, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return ref, fmt.Errorf("error reading response body: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return ref, fmt.Errorf("failed to resolve %s: received non-200 response: %d %s", ref.URL(), resp.StatusCode, resp.Status)
+	}
+
+	var resolved struct {
+		UCID string `json:"ucid"`
+	}
+	if err := json.Unmarshal(body, &resolved); err != nil {
+		return ref, fmt.Errorf("error parsing JSON: %v", err)
+	}
+	if resolved.UCID == "" {
+		return ref, fmt.Errorf("%s does not resolve to a channel", ref.URL())
+	}
+
+	ref.Kind = RefChannel
+	ref.ChannelID = resolved.UCID
+	return ref, nil
+}
@@ -0,0 +1,121 @@
+package youtube
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseURL(t *testing.T) {
+	const (
+		videoID    = "dQw4w9WgXcQ"
+		channelID  = "UCuAXFkgsw1L7xaCfnd5JJOw"
+		playlistID = "PLFgquLnL59alCl_2TQvOiD5Vgm1hCaGSI"
+	)
+
+	tests := []struct {
+		name string
+		url  string
+		want Ref
+	}{
+		{"watch", "https://www.youtube.com/watch?v=" + videoID, Ref{Kind: RefVideo, VideoID: videoID}},
+		{"watch without scheme", "youtube.com/watch?v=" + videoID, Ref{Kind: RefVideo, VideoID: videoID}},
+		{"mobile", "https://m.youtube.com/watch?v=" + videoID + "&feature=share", Ref{Kind: RefVideo, VideoID: videoID}},
+		{"music", "https://music.youtube.com/watch?v=" + videoID, Ref{Kind: RefVideo, VideoID: videoID}},
+		{"watch in playlist", "https://www.youtube.com/watch?v=" + videoID + "&list=" + playlistID + "&index=2",
+			Ref{Kind: RefVideo, VideoID: videoID, PlaylistID: playlistID}},
+		{"watch with seconds", "https://www.youtube.com/watch?v=" + videoID + "&t=90",
+			Ref{Kind: RefVideo, VideoID: videoID, Start: 90 * time.Second}},
+		{"watch with units", "https://www.youtube.com/watch?v=" + videoID + "&t=1h2m3s",
+			Ref{Kind: RefVideo, VideoID: videoID, Start: time.Hour + 2*time.Minute + 3*time.Second}},
+		{"timestamp in fragment", "https://www.youtube.com/watch?v=" + videoID + "#t=1m30s",
+			Ref{Kind: RefVideo, VideoID: videoID, Start: 90 * time.Second}},
+		{"short link", "https://youtu.be/" + videoID + "?si=abc&t=42", Ref{Kind: RefVideo, VideoID: videoID, Start: 42 * time.Second}},
+		{"shorts", "https://www.youtube.com/shorts/" + videoID, Ref{Kind: RefVideo, VideoID: videoID}},
+		{"embed", "https://www.youtube-nocookie.com/embed/" + videoID + "?start=10", Ref{Kind: RefVideo, VideoID: videoID, Start: 10 * time.Second}},
+		{"live", "https://www.youtube.com/live/" + videoID + "?feature=share", Ref{Kind: RefVideo, VideoID: videoID}},
+		{"invidious", "https://invidious.jing.rocks/watch?v=" + videoID, Ref{Kind: RefVideo, VideoID: videoID}},
+		{"piped", "https://piped.video/watch?v=" + videoID, Ref{Kind: RefVideo, VideoID: videoID}},
+		{"playlist", "https://www.youtube.com/playlist?list=" + playlistID, Ref{Kind: RefPlaylist, PlaylistID: playlistID}},
+		{"watch with only a playlist", "https://www.youtube.com/watch?list=" + playlistID, Ref{Kind: RefPlaylist, PlaylistID: playlistID}},
+		{"channel", "https://www.youtube.com/channel/" + channelID, Ref{Kind: RefChannel, ChannelID: channelID}},
+		{"channel tab", "https://www.youtube.com/channel/" + channelID + "/videos", Ref{Kind: RefChannel, ChannelID: channelID}},
+		{"handle", "https://www.youtube.com/@LinusTechTips", Ref{Kind: RefHandle, Handle: "LinusTechTips"}},
+		{"handle tab", "https://www.youtube.com/@veritasium/videos", Ref{Kind: RefHandle, Handle: "veritasium"}},
+		{"custom URL", "https://www.youtube.com/c/LinusTechTips", Ref{Kind: RefCustomURL, CustomName: "LinusTechTips"}},
+		{"legacy user", "https://www.youtube.com/user/LinusTechTips", Ref{Kind: RefUser, CustomName: "LinusTechTips"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseURL(tt.url)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseURL_Unsupported(t *testing.T) {
+	for _, raw := range []string{
+		"",
+		"https://www.youtube.com/",
+		"https://www.youtube.com/feed/subscriptions",
+		"https://www.youtube.com/watch?v=tooshort",
+		"https://youtu.be/",
+		"https://www.youtube.com/channel/notachannel",
+		"https://www.youtube.com/playlist",
+		"just some search words",
+	} {
+		_, err := ParseURL(raw)
+		assert.ErrorIs(t, err, ErrUnsupportedURL, raw)
+	}
+}
+
+func TestRefURL_RoundTrip(t *testing.T) {
+	for _, ref := range []Ref{
+		{Kind: RefVideo, VideoID: "dQw4w9WgXcQ", PlaylistID: "PL123", Start: 75 * time.Second},
+		{Kind: RefCustomURL, CustomName: "LinusTechTips"},
+		{Kind: RefUser, CustomName: "LinusTechTips"},
+	} {
+		parsed, err := ParseURL(ref.URL())
+		require.NoError(t, err)
+		assert.Equal(t, ref, parsed)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	var gotURL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/resolveurl", r.URL.Path)
+		gotURL = r.URL.Query().Get("url")
+		w.Write([]byte(`{"ucid":"UCXuqSBlHAE6Xw-yeJA0Tunw","pageType":"WEB_PAGE_TYPE_CHANNEL"}`))
+	}))
+	defer server.Close()
+
+	search := NewClient(Config{InvidiousURL: server.URL}).Search()
+	ref, err := search.Resolve(Ref{Kind: RefHandle, Handle: "LinusTechTips"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://www.youtube.com/@LinusTechTips", gotURL)
+	assert.Equal(t, RefChannel, ref.Kind)
+	assert.Equal(t, "UCXuqSBlHAE6Xw-yeJA0Tunw", ref.ChannelID)
+	assert.Equal(t, "LinusTechTips", ref.Handle)
+
+	// Videos need no lookup
+	video := Ref{Kind: RefVideo, VideoID: "dQw4w9WgXcQ"}
+	resolved, err := search.Resolve(video)
+	require.NoError(t, err)
+	assert.Equal(t, video, resolved)
+}
+
+func TestResolve_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"not found"}`, http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	_, err := NewClient(Config{InvidiousURL: server.URL}).Search().Resolve(Ref{Kind: RefCustomURL, CustomName: "nobody"})
+	require.Error(t, err)
+}
@@ -0,0 +1,139 @@
+package youtube
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// Rating is a rating the authenticated user can give to a video
+type Rating string
+
+const (
+	RatingLike    Rating = "like"
+	RatingDislike Rating = "dislike"
+	RatingNone    Rating = "none"
+)
+
+// VideosService handles actions on videos for the authenticated user
+type VideosService struct {
+	client *Client
+}
+
+// NewVideosService creates a new videos service
+func (c *Client) Videos() *VideosService {
+	return &VideosService{client: c}
+}
+
+// Rate likes, dislikes or clears the rating of a video. Requires a client created with WriteAccess.
+func (s *VideosService) Rate(videoID string, rating Rating) error {
+	params := url.Values{}
+	params.Set("id", videoID)
+	params.Set("rating", string(rating))
+
+	fullURL := fmt.Sprintf("%s/videos/rate?%s", s.client.apiBaseURL, params.Encode())
+	if err := s.client.doAPI(http.MethodPost, fullURL, nil, nil); err != nil {
+		return fmt.Errorf("failed to rate video %s: %w", videoID, err)
+	}
+	return nil
+}
+
+// videosResponse is the YouTube Data API videos.list response
+type videosResponse struct {
+	NextPageToken string `json:"nextPageToken"`
+	Items         []struct {
+		ID      string `json:"id"`
+		Snippet struct {
+			Title        string        `json:"title"`
+			Description  string        `json:"description"`
+			ChannelTitle string        `json:"channelTitle"`
+			ChannelID    string        `json:"channelId"`
+			PublishedAt  string        `json:"publishedAt"`
+			Thumbnails   apiThumbnails `json:"thumbnails"`
+			// LiveBroadcastContent is "live", "upcoming" or "none"
+			LiveBroadcastContent string `json:"liveBroadcastContent"`
+		} `json:"snippet"`
+		LiveStreamingDetails struct {
+			ScheduledStartTime string `json:"scheduledStartTime"`
+		} `json:"liveStreamingDetails"`
+		ContentDetails struct {
+			Duration string `json:"duration"`
+		} `json:"contentDetails"`
+		Statistics struct {
+			ViewCount string `json:"viewCount"`
+		} `json:"statistics"`
+	} `json:"items"`
+}
+
+// Liked lists one page of the videos the authenticated user liked
+func (s *VideosService) Liked(pageToken string) (VideoPage, error) {
+	params := url.Values{}
+	params.Set("part", "snippet,contentDetails,statistics,liveStreamingDetails")
+	params.Set("myRating", string(RatingLike))
+	params.Set("maxResults", "50")
+	if pageToken != "" {
+		params.Set("pageToken", pageToken)
+	}
+
+	var response videosResponse
+	if err := s.client.doAPI(http.MethodGet, fmt.Sprintf("%s/videos?%s", s.client.apiBaseURL, params.Encode()), nil, &response); err != nil {
+		return VideoPage{}, fmt.Errorf("failed to list liked videos: %w", err)
+	}
+
+	page := VideoPage{NextPageToken: response.NextPageToken}
+	for _, item := range response.Items {
+		video := SearchResultItem{
+			Type:            "video",
+			Title:           item.Snippet.Title,
+			VideoID:         item.ID,
+			Author:          item.Snippet.ChannelTitle,
+			AuthorID:        item.Snippet.ChannelID,
+			Description:     item.Snippet.Description,
+			VideoThumbnails: item.Snippet.Thumbnails.toVideoThumbnails(),
+			LengthSeconds:   int32(parseISODuration(item.ContentDetails.Duration).Seconds()),
+			LiveNow:         item.Snippet.LiveBroadcastContent == "live",
+			IsUpcoming:      item.Snippet.LiveBroadcastContent == "upcoming",
+		}
+		if start, err := time.Parse(time.RFC3339, item.LiveStreamingDetails.ScheduledStartTime); err == nil && video.IsUpcoming {
+			video.PremiereTimestamp = start.Unix()
+		}
+		if views, err := strconv.ParseInt(item.Statistics.ViewCount, 10, 64); err == nil {
+			video.ViewCount = views
+			video.ViewCountText = fmt.Sprintf("%d views", views)
+		}
+		if published, err := time.Parse(time.RFC3339, item.Snippet.PublishedAt); err == nil {
+			video.Published = published.Unix()
+			video.PublishedText = published.Format("2006-01-02")
+		}
+		page.Items = append(page.Items, video)
+	}
+	markShorts(page.Items)
+	return page, nil
+}
+
+var isoDurationPattern = regexp.MustCompile(`^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseISODuration parses the ISO 8601 durations of the Data API, such as PT1H2M3S
+func parseISODuration(value string) time.Duration {
+	match := isoDurationPattern.FindStringSubmatch(value)
+	if match == nil {
+		return 0
+	}
+
+	units := []time.Duration{24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var duration time.Duration
+	for i, unit := range units {
+		if match[i+1] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(match[i+1])
+		if err != nil {
+			return 0
+		}
+		duration += time.Duration(n) * unit
+	}
+	return duration
+}
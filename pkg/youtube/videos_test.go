@@ -0,0 +1,22 @@
+package youtube
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseISODuration(t *testing.T) {
+	tests := map[string]time.Duration{
+		"PT1H2M3S": time.Hour + 2*time.Minute + 3*time.Second,
+		"PT45S":    45 * time.Second,
+		"PT10M":    10 * time.Minute,
+		"P1DT1S":   24*time.Hour + time.Second,
+		"P0D":      0,
+		"invalid":  0,
+	}
+	for input, expected := range tests {
+		assert.Equal(t, expected, parseISODuration(input), input)
+	}
+}
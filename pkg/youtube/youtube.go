@@ -7,14 +7,17 @@ import (
 	"fmt"
 )
 
+// libraryVersion is reported by Version and in DefaultUserAgent
+const libraryVersion = "1.0.0"
+
 // YouTube represents the main YouTube API client with all services
 type YouTube struct {
 	client *Client
 }
 
-// New creates a new YouTube API instance with the provided configuration
-func New(config Config) *YouTube {
-	client := NewClient(config)
+// New creates a new YouTube API instance with the provided configuration and options
+func New(config Config, opts ...Option) *YouTube {
+	client := NewClient(config, opts...)
 	return &YouTube{
 		client: client,
 	}
@@ -35,6 +38,16 @@ func (yt *YouTube) Subscriptions() *SubscriptionsService {
 	return yt.client.Subscriptions()
 }
 
+// Videos returns the videos service
+func (yt *YouTube) Videos() *VideosService {
+	return yt.client.Videos()
+}
+
+// Playlists returns the playlists service
+func (yt *YouTube) Playlists() *PlaylistsService {
+	return yt.client.Playlists()
+}
+
 // Auth returns the authentication service
 func (yt *YouTube) Auth() *AuthService {
 	return yt.client.Auth()
@@ -72,19 +85,35 @@ func (yt *YouTube) GetSubscriptionVideos() ([]SearchResultItem, error) {
 	return yt.Subscriptions().GetAllVideos()
 }
 
-// Authenticate is a convenience method for OAuth2 authentication
-func (yt *YouTube) Authenticate() error {
-	client, err := yt.Auth().Authenticate()
+// GetMyPlaylists is a convenience method for listing the authenticated user's playlists
+func (yt *YouTube) GetMyPlaylists(pageToken string) (PlaylistPage, error) {
+	return yt.Playlists().Mine(pageToken)
+}
+
+// GetLikedVideos is a convenience method for listing the authenticated user's liked videos
+func (yt *YouTube) GetLikedVideos(pageToken string) (VideoPage, error) {
+	return yt.Videos().Liked(pageToken)
+}
+
+// ResolveURL is a convenience method parsing a URL and resolving handles and custom URLs
+// to channel IDs
+func (yt *YouTube) ResolveURL(rawURL string) (Ref, error) {
+	ref, err := ParseURL(rawURL)
 	if err != nil {
-		return err
+		return Ref{}, err
 	}
-	yt.client.SetHTTPClient(client)
-	return nil
+	return yt.Search().Resolve(ref)
+}
+
+// Authenticate is a convenience method for OAuth2 authentication.
+// The token source is set on the first call and reused by the next ones, until Logout.
+func (yt *YouTube) Authenticate() error {
+	return yt.client.authenticateOnce(yt.Auth().TokenSource)
 }
 
 // Version returns the library version
 func Version() string {
-	return "1.0.0"
+	return libraryVersion
 }
 
 // Example demonstrates basic usage of the library
@@ -94,7 +123,6 @@ func Example() {
 		InvidiousURL: "https://invidious.example.com",
 		ClientID:     "your-client-id",
 		ClientSecret: "your-client-secret",
-		RedirectURL:  "http://localhost:8080/oauth2callback",
 	}
 	
 	_ = New(config) // Create client (unused in example)